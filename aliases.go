@@ -0,0 +1,50 @@
+package lorem
+
+import (
+	"reflect"
+	"strings"
+)
+
+// WithFieldNames lets fields be named by the given struct tags, tried in
+// order, as well as by their Go names. With WithFieldNames("json") a CreatedAt
+// field tagged `json:"created_at"` can be given as "created_at" in
+// the paths of WithSpecs, WithOnly and WithExclude, in the references of
+// from, after and before, and is named that way in errors.
+func WithFieldNames(tags ...string) Option {
+	return func(g *Generator) {
+		g.nameTags = append(g.nameTags, tags...)
+	}
+}
+
+// WithSkipIgnored leaves fields tagged `json:"-"` alone, as well
+// as fields tagged "-" by any of the WithFieldNames tags
+func WithSkipIgnored() Option {
+	return func(g *Generator) {
+		g.skipIgnored = true
+	}
+}
+
+// fieldName returns the name of sf from the first of the generator's name
+// tags that gives one, or its Go name if none do
+func (g *Generator) fieldName(sf reflect.StructField) string {
+	for _, key := range g.nameTags {
+		name, _, _ := strings.Cut(sf.Tag.Get(key), ",")
+		if name != "" && name != "-" {
+			return name
+		}
+	}
+	return sf.Name
+}
+
+// ignored reports whether sf is to be left alone by WithSkipIgnored
+func (g *Generator) ignored(sf reflect.StructField) bool {
+	if !g.skipIgnored {
+		return false
+	}
+	for _, key := range append([]string{"json"}, g.nameTags...) {
+		if sf.Tag.Get(key) == "-" {
+			return true
+		}
+	}
+	return false
+}
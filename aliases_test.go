@@ -0,0 +1,171 @@
+package lorem
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+type AliasAddress struct {
+	City    string `json:"city"`
+	ZipCode string `yaml:"zip_code"`
+}
+
+type AliasStruct struct {
+	ID        int          `db:"id"`
+	Title     string       `json:"title"`
+	Slug      string       `json:"slug" lorem:"from=title"`
+	CreatedAt time.Time    `json:"created_at,omitempty"`
+	UpdatedAt time.Time    `json:"updated_at" lorem:"after=created_at"`
+	Secret    string       `json:"-"`
+	Address   AliasAddress `json:"address"`
+}
+
+func TestWithFieldNames(t *testing.T) {
+	var as AliasStruct
+	err := Fill(&as, WithFieldNames("json", "yaml"), WithSpecs(map[string]string{
+		"title":            ",hello world",
+		"address.city":     ",Springfield",
+		"address.zip_code": ",12345",
+	}))
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	if as.Title != "hello world" || as.Slug != "hello world" {
+		t.Errorf("Title: expected spec by json name, got %q and %q", as.Title, as.Slug)
+	}
+	if as.Address.City != "Springfield" || as.Address.ZipCode != "12345" {
+		t.Errorf("Address: expected specs by json and yaml names, got %+v", as.Address)
+	}
+	if !as.UpdatedAt.After(as.CreatedAt) {
+		t.Errorf("UpdatedAt: expected after CreatedAt, got %v and %v", as.UpdatedAt, as.CreatedAt)
+	}
+	if as.Secret == "" {
+		t.Error("Secret: expected json:\"-\" field to be filled without WithSkipIgnored")
+	}
+}
+
+func TestWithFieldNamesGoNames(t *testing.T) {
+	var as AliasStruct
+	err := Fill(&as, WithFieldNames("json"), WithSpecs(map[string]string{"Address.City": ",Shelbyville"}))
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if as.Address.City != "Shelbyville" {
+		t.Errorf("Address.City: expected Go names to still work, got %q", as.Address.City)
+	}
+}
+
+func TestWithFieldNamesPartial(t *testing.T) {
+	var as AliasStruct
+	if err := Fill(&as, WithFieldNames("json", "db"), WithOnly("id", "address.city")); err != nil {
+		t.Fatal(err.Error())
+	}
+	if as.ID == 0 || as.Address.City == "" {
+		t.Errorf("expected id and address.city to be filled, got %+v", as)
+	}
+	if as.Title != "" || as.Address.ZipCode != "" {
+		t.Errorf("expected only id and address.city to be filled, got %+v", as)
+	}
+
+	as = AliasStruct{}
+	if err := Fill(&as, WithFieldNames("json"), WithExclude("address")); err != nil {
+		t.Fatal(err.Error())
+	}
+	if as.Address != (AliasAddress{}) {
+		t.Errorf("Address: expected excluded by json name, got %+v", as.Address)
+	}
+}
+
+func TestWithSkipIgnored(t *testing.T) {
+	var as AliasStruct
+	if err := Fill(&as, WithFieldNames("json"), WithSkipIgnored()); err != nil {
+		t.Fatal(err.Error())
+	}
+	if as.Secret != "" {
+		t.Errorf("Secret: expected json:\"-\" field to be skipped, got %q", as.Secret)
+	}
+	if as.Title == "" {
+		t.Error("Title: expected to be filled")
+	}
+}
+
+func TestWithFieldNamesErrors(t *testing.T) {
+	type Broken struct {
+		CreatedAt time.Time `json:"created_at" lorem:"after=updated_at"`
+		UpdatedAt time.Time `json:"updated_at" lorem:"after=created_at"`
+	}
+	var b Broken
+	err := Fill(&b, WithFieldNames("json"))
+	var perr *ParseError
+	if !errors.As(err, &perr) {
+		t.Fatalf("expected a ParseError, got %v", err)
+	}
+	if perr.FieldName != "created_at" {
+		t.Errorf("expected the error to name created_at, got %q", perr.FieldName)
+	}
+	if !strings.Contains(perr.Message, "created_at -> updated_at -> created_at") {
+		t.Errorf("expected the cycle in json names, got %q", perr.Message)
+	}
+
+	type Address struct {
+		StartAt time.Time `json:"start_at"`
+		EndAt   time.Time `json:"end_at" lorem:"after=finish"`
+	}
+	var nested struct {
+		HomeAddress Address `json:"home_address"`
+	}
+	err = Fill(&nested, WithFieldNames("json"))
+	if !errors.As(err, &perr) {
+		t.Fatalf("expected a ParseError, got %v", err)
+	}
+	if perr.FieldName != "home_address.end_at" || perr.TypeName != "time.Time" || perr.Tag != "after=finish" {
+		t.Errorf("expected the error to name the nested field home_address.end_at, got %+v", perr)
+	}
+
+	type Taken struct {
+		Code string `json:"code" lorem:"word,1,1,unique"`
+	}
+	g := NewGenerator(WithFieldNames("json"), WithUniqueTries(5))
+	for i := 0; i < 100; i++ {
+		var tk Taken
+		if err = g.Fill(&tk); err != nil {
+			break
+		}
+	}
+	if !errors.Is(err, ErrNotUnique) || !strings.Contains(err.Error(), "for code after") {
+		t.Errorf("expected ErrNotUnique naming code, got %v", err)
+	}
+}
+
+type AliasUser struct {
+	City string `json:"city"`
+}
+
+type AliasAccount struct {
+	User AliasUser `json:"user"`
+}
+
+type AliasProject struct {
+	User AliasUser `json:"owner"`
+}
+
+func TestWithFieldNamesPerRoot(t *testing.T) {
+	g := NewGenerator(WithFieldNames("json"), WithSpecs(map[string]string{
+		"owner.city": ",X",
+		"user.city":  ",Y",
+	}))
+	var a AliasAccount
+	var p AliasProject
+	if err := g.Fill(&a); err != nil {
+		t.Fatal(err.Error())
+	}
+	if err := g.Fill(&p); err != nil {
+		t.Fatal(err.Error())
+	}
+	if a.User.City != "Y" || p.User.City != "X" {
+		t.Errorf("expected the same Go path to be named by each root's tags, got %q and %q", a.User.City, p.User.City)
+	}
+}
@@ -0,0 +1,110 @@
+package lorem
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"reflect"
+)
+
+var (
+	errInvalidSlice  = errors.New("must provide a pointer to a slice of structs or struct pointers")
+	errNegativeCount = errors.New("can't fill a negative number of values")
+)
+
+// FillN sets the slice slicePtr points to (a *[]T or *[]*T, where T is
+// a struct) to n newly filled values
+func FillN(n int, slicePtr interface{}, opts ...Option) error {
+	return NewGenerator(opts...).FillN(n, slicePtr)
+}
+
+// FillN sets the slice slicePtr points to (a *[]T or *[]*T, where T is
+// a struct) to n newly filled values, all made by this generator, so
+// fields tagged unique are unique across the slice
+func (g *Generator) FillN(n int, slicePtr interface{}) error {
+	value := reflect.ValueOf(slicePtr)
+	if value.Kind() != reflect.Ptr || value.Elem().Kind() != reflect.Slice {
+		return errInvalidSlice
+	}
+	typ := value.Elem().Type()
+	elem := typ.Elem()
+	if elem.Kind() == reflect.Ptr {
+		elem = elem.Elem()
+	}
+	if elem.Kind() != reflect.Struct {
+		return errInvalidSlice
+	}
+	if n < 0 {
+		return errNegativeCount
+	}
+
+	sl := reflect.MakeSlice(typ, n, n)
+	for i := 0; i < n; i++ {
+		entry := reflect.New(elem)
+		if err := g.Fill(entry.Interface()); err != nil {
+			return err
+		}
+		if typ.Elem().Kind() == reflect.Ptr {
+			sl.Index(i).Set(entry)
+		} else {
+			sl.Index(i).Set(entry.Elem())
+		}
+	}
+	value.Elem().Set(sl)
+	return nil
+}
+
+// Make returns a newly filled T, which must be a struct.
+// It panics if T can't be filled.
+func Make[T any](opts ...Option) T {
+	var v T
+	if err := NewGenerator(opts...).Fill(&v); err != nil {
+		panic("lorem: Make " + err.Error())
+	}
+	return v
+}
+
+// Seq returns an iterator over n newly filled values of T, a struct,
+// all made by one generator. A negative n never stops. If a value can't
+// be filled the error is yielded and the iteration ends.
+func Seq[T any](n int, opts ...Option) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		g := NewGenerator(opts...)
+		for i := 0; n < 0 || i < n; i++ {
+			var v T
+			if err := g.Fill(&v); err != nil {
+				var zero T
+				yield(zero, err)
+				return
+			}
+			if !yield(v, nil) {
+				return
+			}
+		}
+	}
+}
+
+// Stream sends n newly filled values of T, a struct, all made by one
+// generator. A negative n sends values until ctx is done. The values
+// channel is closed when they have all been sent, ctx is done or a value
+// can't be filled, in which case the error is sent on the error channel.
+func Stream[T any](ctx context.Context, n int, opts ...Option) (<-chan T, <-chan error) {
+	values := make(chan T)
+	errc := make(chan error, 1)
+	go func() {
+		defer close(values)
+		defer close(errc)
+		for v, err := range Seq[T](n, opts...) {
+			if err != nil {
+				errc <- err
+				return
+			}
+			select {
+			case values <- v:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return values, errc
+}
@@ -0,0 +1,112 @@
+package lorem
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestFillN(t *testing.T) {
+	var users []UniqueUser
+	if err := FillN(500, &users); err != nil {
+		t.Fatal(err.Error())
+	}
+	if len(users) != 500 {
+		t.Errorf("Expected %d users, got %d", 500, len(users))
+	}
+	emails := map[string]bool{}
+	for _, u := range users {
+		if emails[u.Email] {
+			t.Errorf("Email: expected unique, got %s twice", u.Email)
+		}
+		emails[u.Email] = true
+	}
+
+	var pointers []*SimpleStruct
+	if err := NewGenerator(WithSeed(1)).FillN(3, &pointers); err != nil {
+		t.Fatal(err.Error())
+	}
+	for _, p := range pointers {
+		if p == nil || p.Word == "" {
+			t.Errorf("Expected filled pointer, got %v", p)
+		}
+	}
+
+	var words []string
+	if err := FillN(3, &words); err != errInvalidSlice {
+		t.Errorf("Expected %v, got %v", errInvalidSlice, err)
+	}
+	if err := FillN(3, users); err != errInvalidSlice {
+		t.Errorf("Expected %v, got %v", errInvalidSlice, err)
+	}
+	if err := FillN(-1, &users); err != errNegativeCount {
+		t.Errorf("Expected %v, got %v", errNegativeCount, err)
+	}
+}
+
+func TestMake(t *testing.T) {
+	ss := Make[SimpleStruct](WithSeed(1))
+	if ss.Word == "" {
+		t.Errorf("Word: expected string not empty, got %s", ss.Word)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected Make to panic for a non struct")
+		}
+	}()
+	Make[string]()
+}
+
+func TestSeq(t *testing.T) {
+	count := 0
+	for u, err := range Seq[UniqueUser](100) {
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		if !strings.Contains(u.Email, "@") {
+			t.Errorf("Email: expected Email to contain '@', got %s", u.Email)
+		}
+		count++
+	}
+	if count != 100 {
+		t.Errorf("Expected %d values, got %d", 100, count)
+	}
+
+	count = 0
+	for range Seq[UniqueUser](-1) {
+		if count++; count == 10 {
+			break
+		}
+	}
+
+	var err error
+	for _, err = range Seq[struct {
+		Letter string `lorem:"oneof,a|b,unique"`
+	}](3) {
+	}
+	if err == nil {
+		t.Error("Expected error, got nil")
+	}
+}
+
+func TestStream(t *testing.T) {
+	values, errc := Stream[UniqueUser](context.Background(), 50)
+	count := 0
+	for range values {
+		count++
+	}
+	if err := <-errc; err != nil {
+		t.Error(err.Error())
+	}
+	if count != 50 {
+		t.Errorf("Expected %d values, got %d", 50, count)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	values, _ = Stream[UniqueUser](ctx, -1)
+	<-values
+	cancel()
+	for range values {
+	}
+}
@@ -0,0 +1,319 @@
+// Command golorem prints placeholder text and dummy records.
+//
+//	golorem words -n 12
+//	golorem sentences -n 3 -min 4 -max 8 -seed 42
+//	golorem paragraphs -n 2 -corpus moby-dick.txt -format json
+//	golorem email -n 100 > emails.txt
+//	golorem fill -n 20 -format csv id=uuid name=name email=email city=word,4,10
+//	golorem fill -n 20 -format ndjson -schema user.schema.json
+//	golorem fill -n 5 -format yaml id=uuid name=name
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+
+	lorem "github.com/axiomzen/golorem"
+	"github.com/axiomzen/golorem/encode"
+	"github.com/axiomzen/golorem/schema"
+)
+
+const usage = `Usage: golorem <command> [flags] [fields]
+
+Commands:
+  words        words of -min to -max letters (default 2 to 10)
+  sentences    sentences of -min to -max words (default 5 to 22)
+  paragraphs   paragraphs of -min to -max sentences (default 2 to 10)
+  email, url, host, uuid, name, phone
+  fill         records with fields given as name=tag, where tag is a lorem
+               tag such as email or word,4,10, or generated from -schema
+
+Run golorem <command> -h for the command's flags.
+`
+
+// ranges are the default -min and -max of the commands that take them
+var ranges = map[string][2]int{
+	"words":      {2, 10},
+	"sentences":  {5, 22},
+	"paragraphs": {2, 10},
+}
+
+// singles are the commands making one kind of value
+var singles = map[string]func(g *lorem.Generator) string{
+	"email": (*lorem.Generator).Email,
+	"url":   (*lorem.Generator).URL,
+	"host":  (*lorem.Generator).Host,
+	"uuid":  (*lorem.Generator).UUID,
+	"name":  (*lorem.Generator).Name,
+	"phone": (*lorem.Generator).Phone,
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+// run runs the command in args, returning the exit status
+func run(args []string, stdout, stderr io.Writer) int {
+	if len(args) == 0 || args[0] == "-h" || args[0] == "-help" || args[0] == "help" {
+		fmt.Fprint(stderr, usage)
+		if len(args) == 0 {
+			return 2
+		}
+		return 0
+	}
+
+	cmd := args[0]
+	_, ranged := ranges[cmd]
+	_, single := singles[cmd]
+	if !ranged && !single && cmd != "fill" {
+		fmt.Fprintf(stderr, "golorem: unknown command %q\n\n%s", cmd, usage)
+		return 2
+	}
+
+	fs := flag.NewFlagSet("golorem "+cmd, flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	n := fs.Int("n", 1, "how many to generate")
+	seed := fs.Int64("seed", 0, "seed for the same output every time")
+	corpus := fs.String("corpus", "", "text `file` to take words from instead of latin")
+	defFormat, formats := "text", "text or json"
+	if cmd == "fill" {
+		defFormat, formats = "json", "json, ndjson, csv or yaml"
+	}
+	format := fs.String("format", defFormat, "output format: "+formats)
+	var min, max *int
+	if ranged {
+		min = fs.Int("min", ranges[cmd][0], "fewest letters, words or sentences")
+		max = fs.Int("max", ranges[cmd][1], "most letters, words or sentences")
+	}
+	var schemaFile *string
+	if cmd == "fill" {
+		schemaFile = fs.String("schema", "", "JSON Schema `file` to generate records from")
+	}
+	if err := fs.Parse(args[1:]); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		return 2
+	}
+
+	opts, err := options(fs, *seed, *corpus)
+	if err == nil && *n < 0 {
+		err = fmt.Errorf("-n must not be negative, got %d", *n)
+	}
+	if err == nil && ranged && (*min < 1 || *max < *min) {
+		err = fmt.Errorf("-min and -max must be positive with -min no more than -max, got %d and %d", *min, *max)
+	}
+	if err == nil && cmd != "fill" && fs.NArg() > 0 {
+		err = fmt.Errorf("unexpected arguments %s", strings.Join(fs.Args(), " "))
+	}
+	if err != nil {
+		fmt.Fprintf(stderr, "golorem %s: %s\n", cmd, err)
+		return 2
+	}
+	g := lorem.NewGenerator(opts...)
+
+	switch {
+	case cmd == "fill":
+		err = fill(stdout, g, *n, *format, *schemaFile, fs.Args())
+	case ranged:
+		err = text(stdout, cmd, *n, *format, func() string {
+			switch cmd {
+			case "words":
+				// max is inclusive here, unlike Word
+				return g.Word(*min, *max+1)
+			case "sentences":
+				return g.Sentence(*min, *max+1)
+			}
+			return g.Paragraph(*min, *max+1)
+		})
+	default:
+		err = text(stdout, cmd, *n, *format, func() string { return singles[cmd](g) })
+	}
+	if err != nil {
+		fmt.Fprintf(stderr, "golorem %s: %s\n", cmd, err)
+		return 1
+	}
+	return 0
+}
+
+// options returns the generator options for the flags that were set
+func options(fs *flag.FlagSet, seed int64, corpus string) ([]lorem.Option, error) {
+	var opts []lorem.Option
+	var err error
+	fs.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "seed":
+			opts = append(opts, lorem.WithSeed(seed))
+		case "corpus":
+			var words []string
+			if words, err = lorem.LoadCorpusFile(corpus); err == nil {
+				opts = append(opts, lorem.WithCorpus(words))
+			}
+		}
+	})
+	return opts, err
+}
+
+// text writes n values from next: as a JSON array, or in text with words
+// on one line, paragraphs separated by blank lines and anything else
+// one per line
+func text(w io.Writer, cmd string, n int, format string, next func() string) error {
+	values := make([]string, n)
+	for i := range values {
+		values[i] = next()
+	}
+
+	switch format {
+	case "json":
+		return json.NewEncoder(w).Encode(values)
+	case "text":
+		sep := "\n"
+		switch cmd {
+		case "words":
+			sep = " "
+		case "paragraphs":
+			sep = "\n\n"
+		}
+		if n == 0 {
+			return nil
+		}
+		_, err := fmt.Fprintln(w, strings.Join(values, sep))
+		return err
+	}
+	return fmt.Errorf("unknown format %q, must be text or json", format)
+}
+
+// fill writes n records made from the JSON Schema file, or else from
+// fields given as name=tag, each written as it's made
+func fill(w io.Writer, g *lorem.Generator, n int, format, schemaFile string, fields []string) error {
+	var next func() (interface{}, error)
+	switch {
+	case schemaFile != "" && len(fields) > 0:
+		return errors.New("give either -schema or fields, not both")
+	case schemaFile != "":
+		s, err := schema.LoadFile(schemaFile)
+		if err != nil {
+			return err
+		}
+		sg := schema.NewGenerator(g, s.Resolve)
+		next = func() (interface{}, error) { return sg.Generate(s) }
+	case len(fields) > 0:
+		typ, err := recordType(fields)
+		if err != nil {
+			return err
+		}
+		next = func() (interface{}, error) {
+			v := reflect.New(typ)
+			err := g.Fill(v.Interface())
+			return v.Elem().Interface(), err
+		}
+	default:
+		return errors.New("give fields as name=tag, or -schema")
+	}
+
+	if format == "csv" && schemaFile != "" {
+		// the columns are every property of every record
+		records := make([]interface{}, n)
+		for i := range records {
+			var err error
+			if records[i], err = next(); err != nil {
+				return err
+			}
+		}
+		return writeCSV(w, records)
+	}
+
+	enc, err := encode.NewEncoder(w, encode.Format(format))
+	if err != nil {
+		return err
+	}
+	for i := 0; i < n; i++ {
+		record, err := next()
+		if err != nil {
+			return err
+		}
+		if err := enc.Encode(record); err != nil {
+			return err
+		}
+	}
+	return enc.Close()
+}
+
+// recordType makes a struct type with a string field for each name=tag
+func recordType(fields []string) (reflect.Type, error) {
+	structFields := make([]reflect.StructField, len(fields))
+	seen := map[string]bool{}
+	for i, field := range fields {
+		name, tag, ok := strings.Cut(field, "=")
+		if !ok || name == "" || strings.ContainsAny(name, "\",` ") {
+			return nil, fmt.Errorf("field %q must be name=tag", field)
+		}
+		if seen[name] {
+			return nil, fmt.Errorf("field %s given twice", name)
+		}
+		seen[name] = true
+		structFields[i] = reflect.StructField{
+			Name: fmt.Sprintf("F%d", i),
+			Type: reflect.TypeOf(""),
+			Tag:  reflect.StructTag(fmt.Sprintf(`json:%q lorem:%q`, name, tag)),
+		}
+	}
+	return reflect.StructOf(structFields), nil
+}
+
+// writeCSV writes records generated from a schema as CSV, with a column
+// per property of any record, where values that aren't strings are
+// written as JSON
+func writeCSV(w io.Writer, records []interface{}) error {
+	var header []string
+	seen := map[string]bool{}
+	for _, record := range records {
+		obj, ok := record.(map[string]interface{})
+		if !ok {
+			return errors.New("csv needs the schema to generate objects")
+		}
+		for name := range obj {
+			if !seen[name] {
+				seen[name] = true
+				header = append(header, name)
+			}
+		}
+	}
+	sort.Strings(header)
+
+	cw := csv.NewWriter(w)
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+	row := make([]string, len(header))
+	for _, record := range records {
+		obj := record.(map[string]interface{})
+		for i, name := range header {
+			row[i] = ""
+			switch v := obj[name].(type) {
+			case nil:
+			case string:
+				row[i] = v
+			default:
+				data, err := json.Marshal(v)
+				if err != nil {
+					return err
+				}
+				row[i] = string(data)
+			}
+		}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
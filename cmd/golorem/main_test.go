@@ -0,0 +1,178 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func golorem(t *testing.T, args ...string) (string, string, int) {
+	t.Helper()
+	var stdout, stderr bytes.Buffer
+	code := run(args, &stdout, &stderr)
+	return stdout.String(), stderr.String(), code
+}
+
+func TestText(t *testing.T) {
+	out, _, code := golorem(t, "words", "-n", "12", "-min", "3", "-max", "3")
+	if code != 0 {
+		t.Fatalf("expected exit 0, got %d", code)
+	}
+	words := strings.Fields(out)
+	if len(words) != 12 {
+		t.Errorf("expected 12 words on one line, got %q", out)
+	}
+	for _, w := range words {
+		if len(w) != 3 {
+			t.Errorf("expected 3 letter words, got %q", w)
+		}
+	}
+
+	out, _, _ = golorem(t, "sentences", "-n", "3")
+	if lines := strings.Split(strings.TrimSpace(out), "\n"); len(lines) != 3 {
+		t.Errorf("expected 3 lines, got %q", out)
+	}
+
+	out, _, _ = golorem(t, "paragraphs", "-n", "2")
+	if n := strings.Count(strings.TrimSpace(out), "\n\n"); n != 1 {
+		t.Errorf("expected 2 paragraphs separated by a blank line, got %q", out)
+	}
+
+	var emails []string
+	out, _, _ = golorem(t, "email", "-n", "5", "-format", "json")
+	if err := json.Unmarshal([]byte(out), &emails); err != nil {
+		t.Fatal(err.Error())
+	}
+	if len(emails) != 5 || !strings.Contains(emails[0], "@") {
+		t.Errorf("expected 5 emails, got %v", emails)
+	}
+
+	for _, cmd := range []string{"url", "host", "uuid", "name", "phone"} {
+		if out, _, code := golorem(t, cmd); code != 0 || strings.TrimSpace(out) == "" {
+			t.Errorf("%s: expected a value, got %d %q", cmd, code, out)
+		}
+	}
+}
+
+func TestSeed(t *testing.T) {
+	a, _, _ := golorem(t, "paragraphs", "-seed", "42")
+	b, _, _ := golorem(t, "paragraphs", "-seed", "42")
+	c, _, _ := golorem(t, "paragraphs", "-seed", "43")
+	if a != b {
+		t.Errorf("expected the same output for the same seed, got %q and %q", a, b)
+	}
+	if a == c {
+		t.Errorf("expected different output for a different seed")
+	}
+}
+
+func TestCorpus(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "corpus.txt")
+	if err := os.WriteFile(name, []byte("Call me Ishmael. Some years ago, never mind how long."), 0o644); err != nil {
+		t.Fatal(err.Error())
+	}
+	out, _, code := golorem(t, "words", "-n", "50", "-corpus", name)
+	if code != 0 {
+		t.Fatalf("expected exit 0, got %d", code)
+	}
+	known := map[string]bool{}
+	for _, w := range strings.Fields("call me ishmael some years ago never mind how long") {
+		known[w] = true
+	}
+	for _, w := range strings.Fields(out) {
+		if !known[strings.ToLower(w)] {
+			t.Errorf("expected words from the corpus, got %q", w)
+		}
+	}
+}
+
+func TestFill(t *testing.T) {
+	out, _, code := golorem(t, "fill", "-n", "4", "-format", "csv", "id=uuid", "email=email", "city=word,4,10")
+	if code != 0 {
+		t.Fatalf("expected exit 0, got %d", code)
+	}
+	rows, err := csv.NewReader(strings.NewReader(out)).ReadAll()
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if len(rows) != 5 || strings.Join(rows[0], ",") != "id,email,city" {
+		t.Fatalf("expected a header and 4 rows, got %q", rows)
+	}
+	for _, row := range rows[1:] {
+		if len(row[0]) != 36 || !strings.Contains(row[1], "@") || len(row[2]) < 4 {
+			t.Errorf("expected a filled row, got %q", row)
+		}
+	}
+
+	out, _, _ = golorem(t, "fill", "-n", "3", "-format", "ndjson", "name=name")
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("expected 3 lines, got %q", out)
+	}
+	var record map[string]string
+	if err := json.Unmarshal([]byte(lines[0]), &record); err != nil || record["name"] == "" {
+		t.Errorf("expected a JSON record, got %q", lines[0])
+	}
+
+	out, _, _ = golorem(t, "fill", "-n", "2", "-format", "yaml", "id=uuid", "name=name")
+	if !strings.HasPrefix(out, "- id: ") || strings.Count(out, "\n  name: ") != 2 {
+		t.Errorf("expected 2 YAML records, got %q", out)
+	}
+
+	out, _, code = golorem(t, "fill", "-n", "2", "-format", "csv", "-schema", "../../schema/testdata/user.schema.json")
+	if code != 0 {
+		t.Fatalf("expected exit 0 from a schema, got %d", code)
+	}
+	if rows, err := csv.NewReader(strings.NewReader(out)).ReadAll(); err != nil || len(rows) != 3 {
+		t.Errorf("expected a header and 2 rows from the schema, got %q", out)
+	}
+}
+
+func TestFillDefaultFormat(t *testing.T) {
+	out, stderr, code := golorem(t, "fill", "-n", "2", "id=uuid", "name=name")
+	if code != 0 {
+		t.Fatalf("expected exit 0, got %d: %s", code, stderr)
+	}
+	var records []map[string]string
+	if err := json.Unmarshal([]byte(out), &records); err != nil || len(records) != 2 {
+		t.Errorf("expected a JSON array of 2 records by default, got %q", out)
+	}
+
+	_, stderr, _ = golorem(t, "fill", "-h")
+	if !strings.Contains(stderr, `(default "json")`) {
+		t.Errorf("expected json as the default format in the help, got %q", stderr)
+	}
+}
+
+func TestErrors(t *testing.T) {
+	for _, args := range [][]string{
+		{},
+		{"nothing"},
+		{"words", "-n", "lots"},
+		{"words", "-n", "-1"},
+		{"words", "-min", "5", "-max", "2"},
+		{"email", "extra"},
+		{"words", "-corpus", "missing.txt"},
+	} {
+		if _, stderr, code := golorem(t, args...); code != 2 || stderr == "" {
+			t.Errorf("%q: expected a usage error, got %d %q", args, code, stderr)
+		}
+	}
+
+	for _, args := range [][]string{
+		{"email", "-format", "xml"},
+		{"fill", "-format", "xml", "a=word"},
+		{"fill"},
+		{"fill", "-schema", "missing.json"},
+		{"fill", "name"},
+		{"fill", "a=word", "a=email"},
+	} {
+		if _, stderr, code := golorem(t, args...); code != 1 || stderr == "" {
+			t.Errorf("%q: expected an error, got %d %q", args, code, stderr)
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package lorem
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"os"
+	"strings"
+	"unicode"
+)
+
+var errEmptyCorpus = errors.New("corpus has no words")
+
+// WithCorpus makes the generator take its words (and so its sentences
+// and paragraphs) from words instead of the built in latin word list.
+// Words of the length asked for are used where there are any, and
+// otherwise the closest length available.
+func WithCorpus(words []string) Option {
+	return func(g *Generator) {
+		if byLen := wordsByLen(words); len(byLen) > 0 {
+			g.corpus = byLen
+		}
+	}
+}
+
+// LoadCorpus reads the words of a text, suitable for WithCorpus. Words
+// are separated by white space, lower cased and stripped of punctuation.
+func LoadCorpus(r io.Reader) ([]string, error) {
+	var words []string
+	scanner := bufio.NewScanner(r)
+	scanner.Split(bufio.ScanWords)
+	for scanner.Scan() {
+		word := strings.TrimFunc(scanner.Text(), func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+		})
+		if word != "" {
+			words = append(words, strings.ToLower(word))
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(words) == 0 {
+		return nil, errEmptyCorpus
+	}
+	return words, nil
+}
+
+// LoadCorpusFile reads the words of a text file, suitable for WithCorpus
+func LoadCorpusFile(name string) ([]string, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return LoadCorpus(f)
+}
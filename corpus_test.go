@@ -0,0 +1,78 @@
+package lorem
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestLoadCorpus(t *testing.T) {
+	words, err := LoadCorpus(strings.NewReader("The quick, brown fox -- jumps over\nthe \"lazy\" dog."))
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	want := "the quick brown fox jumps over the lazy dog"
+	if got := strings.Join(words, " "); got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+
+	if _, err := LoadCorpus(strings.NewReader(" -- ... ")); err == nil {
+		t.Error("expected an error for a corpus without words")
+	}
+	if _, err := LoadCorpusFile("testdata/missing.txt"); err == nil {
+		t.Error("expected an error for a missing file")
+	}
+}
+
+func TestWithCorpus(t *testing.T) {
+	corpus := []string{"alpha", "beta", "gamma", "delta", "epsilon"}
+	known := map[string]bool{}
+	for _, w := range corpus {
+		known[w] = true
+	}
+
+	g := NewGenerator(WithCorpus(corpus), WithSeed(1))
+	for i := 0; i < 50; i++ {
+		if w := g.Word(5, 6); w != "alpha" && w != "gamma" && w != "delta" {
+			t.Errorf("Word: expected a five letter word from the corpus, got %q", w)
+		}
+		if w := g.Word(1, 3); w != "beta" {
+			t.Errorf("Word: expected the closest length from the corpus, got %q", w)
+		}
+		for _, w := range strings.Fields(g.Paragraph(1, 3)) {
+			if w = strings.ToLower(strings.Trim(w, ".,")); !known[w] {
+				t.Errorf("Paragraph: expected only corpus words, got %q", w)
+			}
+		}
+	}
+
+	var ss SimpleStruct
+	if err := Fill(&ss, WithCorpus(corpus)); err != nil {
+		t.Fatal(err.Error())
+	}
+}
+
+func TestWithCorpusUniform(t *testing.T) {
+	// words of other lengths between them don't make some words likelier
+	g := NewGenerator(WithCorpus([]string{"aa", "xxxx", "xxxx", "xxxx", "bb"}), WithSeed(1))
+	counts := map[string]int{}
+	for i := 0; i < 1000; i++ {
+		counts[g.Word(2, 3)]++
+	}
+	if counts["aa"] < 400 || counts["bb"] < 400 {
+		t.Errorf("expected each two letter word about half the time, got %v", counts)
+	}
+}
+
+func TestWithCorpusUnicode(t *testing.T) {
+	g := NewGenerator(WithCorpus([]string{"école", "über", "ça"}), WithSeed(1))
+	for i := 0; i < 20; i++ {
+		s := g.Sentence(3, 6)
+		if !utf8.ValidString(s) {
+			t.Fatalf("Sentence: expected valid UTF-8, got %q", s)
+		}
+		if !strings.HasPrefix(s, "École") && !strings.HasPrefix(s, "Über") && !strings.HasPrefix(s, "Ça") {
+			t.Errorf("Sentence: expected a capitalized first word, got %q", s)
+		}
+	}
+}
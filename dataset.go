@@ -0,0 +1,314 @@
+package lorem
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Dataset builds related values, such as users, their orders and the
+// orders' items, with foreign keys pointing at generated values:
+//
+//	ds := lorem.NewDataset(lorem.WithSeed(42))
+//	users := ds.Entity("users", User{}, 10)
+//	orders := ds.Children("orders", Order{}, users, 1, 6).Ref("UserID", users, "ID")
+//	items := ds.Children("items", Item{}, orders, 1, 4).Ref("OrderID", orders, "ID")
+//	err := ds.Build()
+//	for _, o := range orders.Values().([]Order) { ... }
+//
+// All values are filled by one generator, so fields tagged
+// unique are unique across the dataset.
+type Dataset struct {
+	g        *Generator
+	entities []*Entity
+	names    map[string]bool
+}
+
+// Entity is one type of value in a Dataset
+type Entity struct {
+	name string
+	typ  reflect.Type
+	ptrs bool
+	// n values, or between min and max for each parent value
+	n        int
+	parent   *Entity
+	min, max int
+	refs     []reference
+	// fields other entities refer to
+	keys []string
+
+	// pointers to the built values, and the index of each one's parent
+	values  []reflect.Value
+	parents []int
+}
+
+// reference is a field set to the key of a value of another entity
+type reference struct {
+	field string
+	to    *Entity
+	key   string
+}
+
+// NewDataset returns a Dataset whose values are filled by
+// a generator configured with opts
+func NewDataset(opts ...Option) *Dataset {
+	return &Dataset{g: NewGenerator(opts...), names: map[string]bool{}}
+}
+
+// Entity adds n values of proto's type, a struct, called name.
+// It panics if proto is not a struct (or struct pointer) or name is
+// already used.
+func (d *Dataset) Entity(name string, proto interface{}, n int) *Entity {
+	typ := reflect.TypeOf(proto)
+	ptrs := typ != nil && typ.Kind() == reflect.Ptr
+	if ptrs {
+		typ = typ.Elem()
+	}
+	if typ == nil || typ.Kind() != reflect.Struct {
+		panic("lorem: Dataset entity " + name + " is not a struct")
+	}
+	if d.names[name] {
+		panic("lorem: Dataset entity " + name + " added twice")
+	}
+	d.names[name] = true
+	e := &Entity{name: name, typ: typ, ptrs: ptrs, n: n}
+	d.entities = append(d.entities, e)
+	return e
+}
+
+// Children adds values of proto's type, a struct, called name, with
+// between min (inclusive) and max (exclusive) of them for each value of
+// parent. Referring to the parent or its ancestors with Ref gives the
+// values of that parent. It panics as Entity does.
+func (d *Dataset) Children(name string, proto interface{}, parent *Entity, min, max int) *Entity {
+	e := d.Entity(name, proto, 0)
+	e.parent, e.min, e.max = parent, min, max
+	return e
+}
+
+// Ref sets field to the key field of a value of to: the value's own
+// parent (or grandparent and so on) if to is an ancestor, and a random
+// value of to otherwise. Fields are Go field names, and field can be of
+// key's type, a pointer to it or a type of the same kind (any size of
+// integer for an integer key).
+//
+// Keys that have no lorem tag (from the struct, WithSpecs, RegisterSpec or
+// inference) are set to a UUID if they are strings, or numbered from 1 if
+// they are integers, so they are unique; tagged keys are filled by their
+// tag, which should be unique, as in `lorem:"uuid"`. Keys and references
+// are set before the other fields are filled, so fields derived from them
+// with from= see their values.
+// Ref panics if either field is missing or the types don't match.
+func (e *Entity) Ref(field string, to *Entity, key string) *Entity {
+	sf, ok := e.typ.FieldByName(field)
+	if !ok {
+		panic("lorem: Dataset entity " + e.name + " has no field " + field)
+	}
+	kf, ok := to.typ.FieldByName(key)
+	if !ok {
+		panic("lorem: Dataset entity " + to.name + " has no field " + key)
+	}
+	if !refersTo(sf.Type, kf.Type) {
+		panic(fmt.Sprintf("lorem: Dataset field %s.%s of type %s can't refer to %s.%s of type %s",
+			e.name, field, sf.Type, to.name, key, kf.Type))
+	}
+	e.refs = append(e.refs, reference{field: field, to: to, key: key})
+	for _, k := range to.keys {
+		if k == key {
+			return e
+		}
+	}
+	to.keys = append(to.keys, key)
+	return e
+}
+
+// refersTo reports whether a field of type dst can be set from a key of type src
+func refersTo(dst, src reflect.Type) bool {
+	if dst.Kind() == reflect.Ptr && src.Kind() != reflect.Ptr {
+		dst = dst.Elem()
+	}
+	return src.AssignableTo(dst) || kindOf(dst) == kindOf(src) && src.ConvertibleTo(dst)
+}
+
+// kindOf returns the kind of typ, with every size of
+// int, uint and float the same
+func kindOf(typ reflect.Type) reflect.Kind {
+	switch typ.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return reflect.Int
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return reflect.Uint
+	case reflect.Float32, reflect.Float64:
+		return reflect.Float64
+	}
+	return typ.Kind()
+}
+
+// Name returns the entity's name
+func (e *Entity) Name() string {
+	return e.name
+}
+
+// Len returns how many values were built
+func (e *Entity) Len() int {
+	return len(e.values)
+}
+
+// Values returns the built values as a slice of the entity's type, so an
+// entity of User{} gives a []User and an entity of &User{} a []*User
+func (e *Entity) Values() interface{} {
+	elem := e.typ
+	if e.ptrs {
+		elem = reflect.PtrTo(e.typ)
+	}
+	values := reflect.MakeSlice(reflect.SliceOf(elem), len(e.values), len(e.values))
+	for i, v := range e.values {
+		if !e.ptrs {
+			v = v.Elem()
+		}
+		values.Index(i).Set(v)
+	}
+	return values.Interface()
+}
+
+// Build fills every entity, parents and referenced entities first, replacing
+// the values of any earlier Build. Reference cycles are reported as errors.
+func (d *Dataset) Build() error {
+	order, err := d.order()
+	if err != nil {
+		return err
+	}
+	for _, e := range order {
+		if err := d.build(e); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// order returns the entities ordered so that each comes after
+// its parent and the entities it refers to
+func (d *Dataset) order() ([]*Entity, error) {
+	const (
+		visiting = 1
+		done     = 2
+	)
+	state := map[*Entity]int{}
+	var order []*Entity
+	var visit func(e *Entity, from string) error
+	visit = func(e *Entity, from string) error {
+		switch state[e] {
+		case visiting:
+			return fmt.Errorf("reference cycle %s -> %s", from, e.name)
+		case done:
+			return nil
+		}
+		state[e] = visiting
+		if e.parent != nil {
+			if err := visit(e.parent, e.name); err != nil {
+				return err
+			}
+		}
+		for _, ref := range e.refs {
+			if err := visit(ref.to, e.name); err != nil {
+				return err
+			}
+		}
+		state[e] = done
+		order = append(order, e)
+		return nil
+	}
+	for _, e := range d.entities {
+		if err := visit(e, e.name); err != nil {
+			return nil, err
+		}
+	}
+	return order, nil
+}
+
+// build sets the keys and references of the values of e, then fills the rest
+func (d *Dataset) build(e *Entity) error {
+	e.values, e.parents = nil, nil
+	if e.parent == nil {
+		for i := 0; i < e.n; i++ {
+			e.parents = append(e.parents, -1)
+		}
+	} else {
+		for p := range e.parent.values {
+			for n := d.g.IntRange(e.min, e.max); n > 0; n-- {
+				e.parents = append(e.parents, p)
+			}
+		}
+	}
+
+	// keys and references are set first and left alone by Fill,
+	// so fields derived from them with from= see their values
+	exclude := d.g.exclude
+	defer func() { d.g.exclude = exclude }()
+	for i := range e.parents {
+		v := reflect.New(e.typ)
+		set := append([]string{}, exclude...)
+		for _, key := range e.keys {
+			sf, _ := e.typ.FieldByName(key)
+			if d.g.fieldTag(e.typ, key, d.g.fieldName(sf), sf) != "" {
+				continue
+			}
+			field := v.Elem().FieldByIndex(sf.Index)
+			switch field.Kind() {
+			case reflect.String:
+				field.SetString(d.g.UUID())
+			case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+				field.SetInt(int64(i + 1))
+			case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+				field.SetUint(uint64(i + 1))
+			default:
+				continue
+			}
+			set = append(set, key)
+		}
+		for _, ref := range e.refs {
+			target, err := d.target(e, i, ref.to)
+			if err != nil {
+				return err
+			}
+			key := target.Elem().FieldByName(ref.key)
+			setRef(v.Elem().FieldByName(ref.field), key)
+			set = append(set, ref.field)
+		}
+
+		d.g.exclude = set
+		if err := d.g.Fill(v.Interface()); err != nil {
+			return fmt.Errorf("%s: %w", e.name, err)
+		}
+		e.values = append(e.values, v)
+	}
+	return nil
+}
+
+// target returns the value of to that the ith value of e refers to
+func (d *Dataset) target(e *Entity, i int, to *Entity) (reflect.Value, error) {
+	for cur := e; cur.parent != nil; cur = cur.parent {
+		i = cur.parents[i]
+		if cur.parent == to {
+			return to.values[i], nil
+		}
+	}
+	if len(to.values) == 0 {
+		return reflect.Value{}, fmt.Errorf("%s refers to %s, which has no values", e.name, to.name)
+	}
+	return to.values[d.g.IntRange(0, len(to.values))], nil
+}
+
+// setRef sets field to key, as checked by refersTo
+func setRef(field, key reflect.Value) {
+	if field.Kind() == reflect.Ptr && key.Kind() != reflect.Ptr {
+		ptr := reflect.New(field.Type().Elem())
+		setRef(ptr.Elem(), key)
+		field.Set(ptr)
+		return
+	}
+	if key.Type().AssignableTo(field.Type()) {
+		field.Set(key)
+		return
+	}
+	field.Set(key.Convert(field.Type()))
+}
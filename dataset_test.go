@@ -0,0 +1,210 @@
+package lorem
+
+import (
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+type DatasetUser struct {
+	ID    string `lorem:"uuid"`
+	Email string `lorem:"email,unique"`
+}
+
+type DatasetProduct struct {
+	ID   int
+	Name string `lorem:"word,4,10"`
+}
+
+type DatasetOrder struct {
+	ID     string
+	UserID string
+	Note   *string `lorem:"-"`
+}
+
+type DatasetItem struct {
+	ID        int64
+	OrderID   *string
+	UserID    string
+	ProductID int64
+	Quantity  int
+}
+
+func buildShop(t *testing.T, opts ...Option) (users, products, orders, items *Entity) {
+	t.Helper()
+	ds := NewDataset(opts...)
+	users = ds.Entity("users", DatasetUser{}, 10)
+	orders = ds.Children("orders", DatasetOrder{}, users, 1, 6).Ref("UserID", users, "ID")
+	items = ds.Children("items", &DatasetItem{}, orders, 1, 4).
+		Ref("OrderID", orders, "ID").
+		Ref("UserID", users, "ID")
+	// declared after the items referring to them, to be built first anyway
+	products = ds.Entity("products", DatasetProduct{}, 5)
+	items.Ref("ProductID", products, "ID")
+	if err := ds.Build(); err != nil {
+		t.Fatal(err.Error())
+	}
+	return users, products, orders, items
+}
+
+func TestDataset(t *testing.T) {
+	usersEntity, productsEntity, ordersEntity, itemsEntity := buildShop(t)
+	users := usersEntity.Values().([]DatasetUser)
+	products := productsEntity.Values().([]DatasetProduct)
+	orders := ordersEntity.Values().([]DatasetOrder)
+	items := itemsEntity.Values().([]*DatasetItem)
+
+	if len(users) != 10 || len(products) != 5 {
+		t.Fatalf("expected 10 users and 5 products, got %d and %d", len(users), len(products))
+	}
+	uuid := regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+
+	perUser := map[string]int{}
+	for _, u := range users {
+		if !uuid.MatchString(u.ID) {
+			t.Errorf("expected a user uuid, got %q", u.ID)
+		}
+		perUser[u.ID] = 0
+	}
+	for i, p := range products {
+		if p.ID != i+1 {
+			t.Errorf("expected untagged integer keys numbered from 1, got %d for product %d", p.ID, i)
+		}
+	}
+
+	orderUser := map[string]string{}
+	perOrder := map[string]int{}
+	for _, o := range orders {
+		if !uuid.MatchString(o.ID) {
+			t.Errorf("expected an untagged string key to get a uuid, got %q", o.ID)
+		}
+		if _, ok := perUser[o.UserID]; !ok {
+			t.Errorf("order %s: expected a generated user, got %q", o.ID, o.UserID)
+		}
+		perUser[o.UserID]++
+		orderUser[o.ID] = o.UserID
+		perOrder[o.ID] = 0
+	}
+	for id, n := range perUser {
+		if n < 1 || n > 5 {
+			t.Errorf("user %s: expected 1 to 5 orders, got %d", id, n)
+		}
+	}
+
+	for _, item := range items {
+		if item.OrderID == nil {
+			t.Fatalf("expected an order id, got %+v", item)
+		}
+		user, ok := orderUser[*item.OrderID]
+		if !ok {
+			t.Errorf("expected a generated order, got %q", *item.OrderID)
+		}
+		if item.UserID != user {
+			t.Errorf("expected the item's user to be its order's user %s, got %s", user, item.UserID)
+		}
+		if item.ProductID < 1 || item.ProductID > 5 {
+			t.Errorf("expected a generated product, got %d", item.ProductID)
+		}
+		perOrder[*item.OrderID]++
+	}
+	for id, n := range perOrder {
+		if n < 1 || n > 3 {
+			t.Errorf("order %s: expected 1 to 3 items, got %d", id, n)
+		}
+	}
+}
+
+func TestDatasetSeed(t *testing.T) {
+	_, _, a, _ := buildShop(t, WithSeed(3))
+	_, _, b, _ := buildShop(t, WithSeed(3))
+	if !reflect.DeepEqual(a.Values(), b.Values()) {
+		t.Error("expected the same dataset for the same seed")
+	}
+}
+
+type DatasetInvoice struct {
+	ID      string
+	Number  string `lorem:"from=ID"`
+	OrderID string
+	Order   string `lorem:"from=OrderID"`
+}
+
+func TestDatasetDerivedKeys(t *testing.T) {
+	ds := NewDataset(WithSpecs(map[string]string{"ID": ",fixed"}))
+	orders := ds.Entity("orders", DatasetOrder{}, 3)
+	invoices := ds.Entity("invoices", DatasetInvoice{}, 3).Ref("OrderID", orders, "ID")
+	ds.Entity("refunds", DatasetInvoice{}, 3).Ref("OrderID", invoices, "ID")
+	if err := ds.Build(); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	for _, inv := range invoices.Values().([]DatasetInvoice) {
+		if inv.ID != "fixed" {
+			t.Errorf("ID: expected the key tagged by WithSpecs to be filled by it, got %q", inv.ID)
+		}
+		if inv.Number != inv.ID || inv.Order != inv.OrderID || inv.OrderID != "fixed" {
+			t.Errorf("expected fields derived from the key and reference to match them, got %+v", inv)
+		}
+	}
+	for _, o := range orders.Values().([]DatasetOrder) {
+		if o.ID != "fixed" {
+			t.Errorf("ID: expected the spec to apply to every entity, got %q", o.ID)
+		}
+	}
+
+	ds = NewDataset()
+	orders = ds.Entity("orders", DatasetOrder{}, 3)
+	invoices = ds.Entity("invoices", DatasetInvoice{}, 3).Ref("OrderID", orders, "ID")
+	ds.Entity("refunds", DatasetInvoice{}, 3).Ref("OrderID", invoices, "ID")
+	if err := ds.Build(); err != nil {
+		t.Fatal(err.Error())
+	}
+	for _, inv := range invoices.Values().([]DatasetInvoice) {
+		if len(inv.ID) != 36 || inv.Number != inv.ID || inv.Order != inv.OrderID {
+			t.Errorf("expected fields derived from the key and reference to match them, got %+v", inv)
+		}
+	}
+}
+
+func TestDatasetErrors(t *testing.T) {
+	ds := NewDataset()
+	a := ds.Entity("a", DatasetOrder{}, 2)
+	b := ds.Entity("b", DatasetOrder{}, 2).Ref("UserID", a, "ID")
+	a.Ref("UserID", b, "ID")
+	if err := ds.Build(); err == nil || !strings.Contains(err.Error(), "reference cycle") {
+		t.Errorf("expected a reference cycle, got %v", err)
+	}
+
+	ds = NewDataset()
+	none := ds.Entity("none", DatasetUser{}, 0)
+	ds.Entity("orders", DatasetOrder{}, 1).Ref("UserID", none, "ID")
+	if err := ds.Build(); err == nil || !strings.Contains(err.Error(), "no values") {
+		t.Errorf("expected an error for nothing to refer to, got %v", err)
+	}
+}
+
+func TestDatasetPanics(t *testing.T) {
+	for name, fn := range map[string]func(ds *Dataset){
+		"not a struct": func(ds *Dataset) { ds.Entity("a", "text", 1) },
+		"added twice":  func(ds *Dataset) { ds.Entity("a", DatasetUser{}, 1); ds.Entity("a", DatasetUser{}, 1) },
+		"missing field": func(ds *Dataset) {
+			ds.Entity("a", DatasetOrder{}, 1).Ref("Nope", ds.Entity("b", DatasetUser{}, 1), "ID")
+		},
+		"missing key": func(ds *Dataset) {
+			ds.Entity("a", DatasetOrder{}, 1).Ref("UserID", ds.Entity("b", DatasetUser{}, 1), "Nope")
+		},
+		"wrong type": func(ds *Dataset) {
+			ds.Entity("a", DatasetOrder{}, 1).Ref("UserID", ds.Entity("b", DatasetProduct{}, 1), "ID")
+		},
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s: expected a panic", name)
+				}
+			}()
+			fn(NewDataset())
+		}()
+	}
+}
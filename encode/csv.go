@@ -0,0 +1,189 @@
+package encode
+
+import (
+	"database/sql/driver"
+	"encoding"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	valuerType        = reflect.TypeOf((*driver.Valuer)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+)
+
+// column is a CSV column and the index of its field
+type column struct {
+	name  string
+	index []int
+}
+
+// csvEncoder writes structs of one type as CSV rows, with a header
+// row made from the first struct's type
+type csvEncoder struct {
+	w    *csv.Writer
+	typ  reflect.Type
+	cols []column
+	row  []string
+}
+
+func newCSVEncoder(w io.Writer) *csvEncoder {
+	return &csvEncoder{w: csv.NewWriter(w)}
+}
+
+func (e *csvEncoder) Encode(v interface{}) error {
+	value := reflect.ValueOf(v)
+	for value.Kind() == reflect.Ptr && !value.IsNil() {
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.Struct {
+		return fmt.Errorf("encode: csv needs structs, got %T", v)
+	}
+
+	if e.typ == nil {
+		e.typ = value.Type()
+		e.cols = columns(e.typ, nil, "", map[reflect.Type]bool{})
+		e.row = make([]string, len(e.cols))
+		header := make([]string, len(e.cols))
+		for i, col := range e.cols {
+			header[i] = col.name
+		}
+		if err := e.w.Write(header); err != nil {
+			return err
+		}
+	} else if value.Type() != e.typ {
+		return fmt.Errorf("encode: csv values must all be %s, got %s", e.typ, value.Type())
+	}
+
+	for i, col := range e.cols {
+		var err error
+		if e.row[i], err = cell(value, col.index); err != nil {
+			return fmt.Errorf("encode: %s: %w", col.name, err)
+		}
+	}
+	return e.w.Write(e.row)
+}
+
+func (e *csvEncoder) Close() error {
+	e.w.Flush()
+	return e.w.Error()
+}
+
+// columns returns the columns of typ, named as encoding/json names them,
+// with structs flattened into columns named prefix.field. Types already
+// being flattened (in seen) are written as JSON, so recursive types end.
+func columns(typ reflect.Type, index []int, prefix string, seen map[reflect.Type]bool) []column {
+	seen[typ] = true
+	defer delete(seen, typ)
+
+	var cols []column
+	for i := 0; i < typ.NumField(); i++ {
+		sf := typ.Field(i)
+		name, _, _ := strings.Cut(sf.Tag.Get("json"), ",")
+		if !sf.IsExported() || name == "-" {
+			continue
+		}
+		idx := append(append([]int{}, index...), i)
+		ft := sf.Type
+		if ft.Kind() == reflect.Ptr {
+			ft = ft.Elem()
+		}
+		if flattened(ft) && !seen[ft] {
+			if sf.Anonymous && name == "" {
+				cols = append(cols, columns(ft, idx, prefix, seen)...)
+				continue
+			}
+			if name == "" {
+				name = sf.Name
+			}
+			cols = append(cols, columns(ft, idx, prefix+name+".", seen)...)
+			continue
+		}
+		if name == "" {
+			name = sf.Name
+		}
+		cols = append(cols, column{name: prefix + name, index: idx})
+	}
+	return cols
+}
+
+// flattened reports whether typ is a struct to be written as columns,
+// rather than one that writes itself as a single value
+func flattened(typ reflect.Type) bool {
+	if typ.Kind() != reflect.Struct {
+		return false
+	}
+	for _, t := range []reflect.Type{typ, reflect.PtrTo(typ)} {
+		if t.Implements(valuerType) || t.Implements(textMarshalerType) || t.Implements(jsonMarshalerType) {
+			return false
+		}
+	}
+	return true
+}
+
+// cell returns the field of v at index as a CSV cell: empty if it's
+// nil or behind a nil pointer, as text for strings, numbers, bools and
+// driver.Valuer or encoding.TextMarshaler types, and as JSON otherwise
+func cell(v reflect.Value, index []int) (string, error) {
+	for _, i := range index {
+		for v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				return "", nil
+			}
+			v = v.Elem()
+		}
+		v = v.Field(i)
+	}
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return "", nil
+		}
+		v = v.Elem()
+	}
+	if (v.Kind() == reflect.Slice || v.Kind() == reflect.Map) && v.IsNil() {
+		return "", nil
+	}
+
+	// make v addressable, for methods on pointers
+	addr := reflect.New(v.Type())
+	addr.Elem().Set(v)
+	switch x := addr.Interface().(type) {
+	case driver.Valuer:
+		dv, err := x.Value()
+		if err != nil || dv == nil {
+			return "", err
+		}
+		if t, ok := dv.(time.Time); ok {
+			return t.Format(time.RFC3339Nano), nil
+		}
+		if b, ok := dv.([]byte); ok {
+			return string(b), nil
+		}
+		v = reflect.ValueOf(dv)
+	case encoding.TextMarshaler:
+		text, err := x.MarshalText()
+		return string(text), err
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		return v.String(), nil
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(v.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'g', -1, v.Type().Bits()), nil
+	}
+	data, err := json.Marshal(v.Interface())
+	return string(data), err
+}
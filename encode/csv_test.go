@@ -0,0 +1,90 @@
+package encode
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/csv"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+type Meta struct {
+	Source string
+}
+
+type Node struct {
+	Name string `json:"name"`
+	Next *Node  `json:"next"`
+}
+
+type Row struct {
+	Meta
+	ID       int            `json:"id"`
+	Secret   string         `json:"-"`
+	Home     *Address       `json:"home"`
+	Work     Address        `json:"work"`
+	Nickname sql.NullString `json:"nickname"`
+	Seen     time.Time      `json:"seen"`
+	Score    *float64       `json:"score"`
+	Tags     []string       `json:"tags"`
+	Node     Node           `json:"node"`
+}
+
+func TestCSV(t *testing.T) {
+	score := 0.5
+	seen := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	rows := []interface{}{
+		Row{
+			Meta:     Meta{Source: "import"},
+			ID:       1,
+			Secret:   "x",
+			Home:     &Address{Street: "1 Main St", City: "Vancouver"},
+			Work:     Address{City: "Burnaby, BC"},
+			Nickname: sql.NullString{String: "ada", Valid: true},
+			Seen:     seen,
+			Score:    &score,
+			Tags:     []string{"a", "b"},
+			Node:     Node{Name: "first", Next: &Node{Name: "second"}},
+		},
+		&Row{ID: 2},
+	}
+
+	var b bytes.Buffer
+	enc, _ := NewEncoder(&b, CSV)
+	for _, row := range rows {
+		if err := enc.Encode(row); err != nil {
+			t.Fatal(err.Error())
+		}
+	}
+	if err := enc.Close(); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	got, err := csv.NewReader(&b).ReadAll()
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	want := [][]string{
+		{"Source", "id", "home.street", "home.city", "work.street", "work.city", "nickname", "seen", "score", "tags", "node.name", "node.next"},
+		{"import", "1", "1 Main St", "Vancouver", "", "Burnaby, BC", "ada", "2020-01-02T03:04:05Z", "0.5", `["a","b"]`, "first", `{"name":"second","next":null}`},
+		{"", "2", "", "", "", "", "", "0001-01-01T00:00:00Z", "", "", "", ""},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expected\n%q\ngot\n%q", want, got)
+	}
+}
+
+func TestCSVErrors(t *testing.T) {
+	enc, _ := NewEncoder(&bytes.Buffer{}, CSV)
+	if err := enc.Encode("text"); err == nil {
+		t.Error("expected an error for a value that isn't a struct")
+	}
+	if err := enc.Encode(Address{}); err != nil {
+		t.Fatal(err.Error())
+	}
+	if err := enc.Encode(Row{}); err == nil || !strings.Contains(err.Error(), "Address") {
+		t.Errorf("expected an error for a different type, got %v", err)
+	}
+}
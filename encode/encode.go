@@ -0,0 +1,111 @@
+// Package encode writes filled values as fixture files, one value at a
+// time, so millions of rows can be written without holding them in memory.
+//
+//	f, _ := os.Create("users.csv")
+//	err := encode.Write[User](f, encode.CSV, 1000000, lorem.WithSeed(42))
+//
+// Fields are named as encoding/json names them, and the JSON, NDJSON and
+// YAML formats write what encoding/json would.
+package encode
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	lorem "github.com/axiomzen/golorem"
+)
+
+// Format is an output format
+type Format string
+
+// The formats
+const (
+	// JSON is a JSON array with one value per line
+	JSON Format = "json"
+	// NDJSON is newline delimited JSON, one value per line
+	NDJSON Format = "ndjson"
+	// CSV has a header row and a row per struct, with nested structs
+	// flattened into columns with dotted names, such as address.city
+	CSV Format = "csv"
+	// YAML is a YAML sequence with an entry per value
+	YAML Format = "yaml"
+)
+
+// Encoder writes values to a writer one at a time
+type Encoder interface {
+	// Encode writes v
+	Encode(v interface{}) error
+	// Close finishes the output, without closing the writer
+	Close() error
+}
+
+// NewEncoder returns an Encoder writing to w in format f
+func NewEncoder(w io.Writer, f Format) (Encoder, error) {
+	switch f {
+	case JSON:
+		return &jsonEncoder{w: w}, nil
+	case NDJSON:
+		return &jsonEncoder{w: w, lines: true}, nil
+	case CSV:
+		return newCSVEncoder(w), nil
+	case YAML:
+		return &yamlEncoder{w: w}, nil
+	}
+	return nil, fmt.Errorf("encode: unknown format %q, must be json, ndjson, csv or yaml", f)
+}
+
+// Write writes n newly filled values of T, a struct, all made by one
+// generator, to w in format f. Each value is written as it's made.
+func Write[T any](w io.Writer, f Format, n int, opts ...lorem.Option) error {
+	enc, err := NewEncoder(w, f)
+	if err != nil {
+		return err
+	}
+	for v, err := range lorem.Seq[T](n, opts...) {
+		if err != nil {
+			return err
+		}
+		if err := enc.Encode(v); err != nil {
+			return err
+		}
+	}
+	return enc.Close()
+}
+
+// jsonEncoder writes a JSON array, or newline delimited JSON if lines is set
+type jsonEncoder struct {
+	w     io.Writer
+	lines bool
+	n     int
+}
+
+func (e *jsonEncoder) Encode(v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	switch {
+	case e.lines:
+		data = append(data, '\n')
+	case e.n == 0:
+		data = append([]byte("[\n"), data...)
+	default:
+		data = append([]byte(",\n"), data...)
+	}
+	e.n++
+	_, err = e.w.Write(data)
+	return err
+}
+
+func (e *jsonEncoder) Close() error {
+	var err error
+	switch {
+	case e.lines:
+	case e.n == 0:
+		_, err = io.WriteString(e.w, "[]\n")
+	default:
+		_, err = io.WriteString(e.w, "\n]\n")
+	}
+	return err
+}
@@ -0,0 +1,92 @@
+package encode
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	lorem "github.com/axiomzen/golorem"
+)
+
+type Address struct {
+	Street string `lorem:"sentence,2,3" json:"street"`
+	City   string `lorem:"word,4,10" json:"city"`
+}
+
+type User struct {
+	ID      string    `lorem:"uuid" json:"id"`
+	Email   string    `lorem:"email" json:"email"`
+	Age     int       `json:"age"`
+	Joined  time.Time `json:"joined"`
+	Address Address   `json:"address"`
+	Tags    []string  `lorem:"[1,3]word,3,6" json:"tags"`
+}
+
+func TestWriteJSON(t *testing.T) {
+	var b bytes.Buffer
+	if err := Write[User](&b, JSON, 5); err != nil {
+		t.Fatal(err.Error())
+	}
+	var users []User
+	if err := json.Unmarshal(b.Bytes(), &users); err != nil {
+		t.Fatal(err.Error())
+	}
+	if len(users) != 5 || users[0].Email == "" || users[0].Address.City == "" {
+		t.Errorf("expected 5 filled users, got %+v", users)
+	}
+	if lines := strings.Count(b.String(), "\n"); lines != 7 {
+		t.Errorf("expected a user per line, got %q", b.String())
+	}
+
+	b.Reset()
+	if err := Write[User](&b, JSON, 0); err != nil || b.String() != "[]\n" {
+		t.Errorf("expected an empty array, got %q %v", b.String(), err)
+	}
+}
+
+func TestWriteNDJSON(t *testing.T) {
+	var b bytes.Buffer
+	if err := Write[User](&b, NDJSON, 1000, lorem.WithSeed(42)); err != nil {
+		t.Fatal(err.Error())
+	}
+	n := 0
+	s := bufio.NewScanner(&b)
+	for s.Scan() {
+		var u User
+		if err := json.Unmarshal(s.Bytes(), &u); err != nil {
+			t.Fatalf("line %d: %s", n, err)
+		}
+		n++
+	}
+	if n != 1000 {
+		t.Errorf("expected 1000 lines, got %d", n)
+	}
+}
+
+func TestWriteSeed(t *testing.T) {
+	now := lorem.WithNow(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC))
+	for _, f := range []Format{JSON, NDJSON, CSV, YAML} {
+		var a, b bytes.Buffer
+		if err := Write[User](&a, f, 3, lorem.WithSeed(7), now); err != nil {
+			t.Fatal(err.Error())
+		}
+		if err := Write[User](&b, f, 3, lorem.WithSeed(7), now); err != nil {
+			t.Fatal(err.Error())
+		}
+		if a.String() != b.String() {
+			t.Errorf("%s: expected the same output for the same seed", f)
+		}
+	}
+}
+
+func TestUnknownFormat(t *testing.T) {
+	if _, err := NewEncoder(&bytes.Buffer{}, "xml"); err == nil {
+		t.Error("expected an error for an unknown format")
+	}
+	if err := Write[User](&bytes.Buffer{}, "xml", 1); err == nil {
+		t.Error("expected Write to return the error")
+	}
+}
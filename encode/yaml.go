@@ -0,0 +1,162 @@
+package encode
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// yamlEncoder writes values as entries of a YAML sequence. Each value is
+// encoded as JSON first, so it's written with the same names and values
+// as encoding/json would write, in the same order.
+type yamlEncoder struct {
+	w io.Writer
+	n int
+}
+
+func (e *yamlEncoder) Encode(v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	node, err := parseNode(dec)
+	if err != nil {
+		return err
+	}
+
+	var b bytes.Buffer
+	b.WriteByte('-')
+	writeYAML(&b, node, 2, true)
+	e.n++
+	_, err = e.w.Write(b.Bytes())
+	return err
+}
+
+func (e *yamlEncoder) Close() error {
+	if e.n == 0 {
+		_, err := io.WriteString(e.w, "[]\n")
+		return err
+	}
+	return nil
+}
+
+// object is a JSON object with its keys in order
+type object struct {
+	keys   []string
+	values []interface{}
+}
+
+// parseNode reads the next JSON value from dec, as an *object, a
+// []interface{}, a string, a json.Number, a bool or nil
+func parseNode(dec *json.Decoder) (interface{}, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	switch tok {
+	case json.Delim('{'):
+		obj := &object{}
+		for dec.More() {
+			key, err := dec.Token()
+			if err != nil {
+				return nil, err
+			}
+			value, err := parseNode(dec)
+			if err != nil {
+				return nil, err
+			}
+			obj.keys = append(obj.keys, key.(string))
+			obj.values = append(obj.values, value)
+		}
+		_, err = dec.Token()
+		return obj, err
+	case json.Delim('['):
+		list := []interface{}{}
+		for dec.More() {
+			value, err := parseNode(dec)
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, value)
+		}
+		_, err = dec.Token()
+		return list, err
+	}
+	return tok, nil
+}
+
+// writeYAML writes v following a "key:" or "-" already written, with its
+// nested lines indented by indent. After a "-" (item is set) a mapping or
+// sequence starts on the same line.
+func writeYAML(b *bytes.Buffer, v interface{}, indent int, item bool) {
+	pad := strings.Repeat(" ", indent)
+	switch v := v.(type) {
+	case *object:
+		if len(v.keys) == 0 {
+			b.WriteString(" {}\n")
+			return
+		}
+		for i, key := range v.keys {
+			switch {
+			case i == 0 && item:
+				b.WriteByte(' ')
+			case i == 0:
+				b.WriteString("\n" + pad)
+			default:
+				b.WriteString(pad)
+			}
+			b.WriteString(yamlString(key) + ":")
+			writeYAML(b, v.values[i], indent+2, false)
+		}
+	case []interface{}:
+		if len(v) == 0 {
+			b.WriteString(" []\n")
+			return
+		}
+		for i, entry := range v {
+			switch {
+			case i == 0 && item:
+				b.WriteByte(' ')
+			case i == 0:
+				b.WriteString("\n" + pad)
+			default:
+				b.WriteString(pad)
+			}
+			b.WriteByte('-')
+			writeYAML(b, entry, indent+2, true)
+		}
+	case string:
+		b.WriteString(" " + yamlString(v) + "\n")
+	case json.Number:
+		b.WriteString(" " + v.String() + "\n")
+	case bool:
+		b.WriteString(" " + strconv.FormatBool(v) + "\n")
+	default:
+		b.WriteString(" null\n")
+	}
+}
+
+// yamlString returns s as a plain YAML scalar if it would be read back
+// as the same string, and double quoted otherwise
+func yamlString(s string) string {
+	if s == "" || s != strings.TrimSpace(s) ||
+		strings.ContainsAny(s[:1], "-?:,[]{}#&*!|>'\"%@`.+0123456789") ||
+		strings.Contains(s, ": ") || strings.Contains(s, " #") || strings.HasSuffix(s, ":") {
+		return strconv.Quote(s)
+	}
+	switch strings.ToLower(s) {
+	case "~", "null", "true", "false", "yes", "no", "on", "off", "y", "n", ".inf", ".nan":
+		return strconv.Quote(s)
+	}
+	for _, r := range s {
+		if !unicode.IsPrint(r) {
+			return strconv.Quote(s)
+		}
+	}
+	return s
+}
@@ -0,0 +1,104 @@
+package encode
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestYAML(t *testing.T) {
+	type entry struct {
+		Name    string                 `json:"name"`
+		Count   int                    `json:"count"`
+		Ratio   float64                `json:"ratio"`
+		Active  bool                   `json:"active"`
+		Parent  *entry                 `json:"parent"`
+		Tags    []string               `json:"tags"`
+		Grid    [][]int                `json:"grid"`
+		Address Address                `json:"address"`
+		Extra   map[string]interface{} `json:"extra"`
+		People  []Address              `json:"people"`
+	}
+	values := []interface{}{
+		entry{
+			Name:    "lorem ipsum",
+			Count:   3,
+			Ratio:   0.25,
+			Active:  true,
+			Tags:    []string{"a", "true", ""},
+			Grid:    [][]int{{1, 2}, {}},
+			Address: Address{Street: "1 Main St", City: "Vancouver"},
+			Extra:   map[string]interface{}{},
+			People:  []Address{{Street: "x", City: "y"}},
+		},
+		"plain",
+		[]int{},
+	}
+
+	var b bytes.Buffer
+	enc, _ := NewEncoder(&b, YAML)
+	for _, v := range values {
+		if err := enc.Encode(v); err != nil {
+			t.Fatal(err.Error())
+		}
+	}
+	if err := enc.Close(); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	want := `- name: lorem ipsum
+  count: 3
+  ratio: 0.25
+  active: true
+  parent: null
+  tags:
+    - a
+    - "true"
+    - ""
+  grid:
+    - - 1
+      - 2
+    - []
+  address:
+    street: "1 Main St"
+    city: Vancouver
+  extra: {}
+  people:
+    - street: x
+      city: "y"
+- plain
+- []
+`
+	if b.String() != want {
+		t.Errorf("expected\n%s\ngot\n%s", want, b.String())
+	}
+
+	b.Reset()
+	enc, _ = NewEncoder(&b, YAML)
+	if err := enc.Close(); err != nil || b.String() != "[]\n" {
+		t.Errorf("expected an empty sequence, got %q %v", b.String(), err)
+	}
+}
+
+func TestYAMLString(t *testing.T) {
+	for s, want := range map[string]string{
+		"lorem":           "lorem",
+		"lorem ipsum":     "lorem ipsum",
+		"ada@example.com": "ada@example.com",
+		"":                `""`,
+		" padded":         `" padded"`,
+		"null":            `"null"`,
+		"Yes":             `"Yes"`,
+		"42":              `"42"`,
+		"2020-01-01":      `"2020-01-01"`,
+		"-dash":           `"-dash"`,
+		"key: value":      `"key: value"`,
+		"a #comment":      `"a #comment"`,
+		"line\nbreak":     `"line\nbreak"`,
+		"*alias":          `"*alias"`,
+		"http://a.com/b":  "http://a.com/b",
+	} {
+		if got := yamlString(s); got != want {
+			t.Errorf("%q: expected %s, got %s", s, want, got)
+		}
+	}
+}
@@ -4,19 +4,19 @@ import (
 	"errors"
 	"fmt"
 	"math"
-	"math/rand"
 	"reflect"
 	"strconv"
 	"strings"
+	"time"
 
 	"regexp"
-
-	"github.com/twinj/uuid"
 )
 
 //
 var errInvalidSpecification = errors.New("must provide a struct pointer")
 
+var errInvalidValue = errors.New("must provide a non nil pointer")
+
 // A ParseError occurs when an environment variable cannot be converted to
 // the type required by a struct field during assignment.
 type ParseError struct {
@@ -24,25 +24,27 @@ type ParseError struct {
 	FieldName string
 	TypeName  string
 	Tag       string
+
+	err error
 }
 
 func (e *ParseError) Error() string {
 	return fmt.Sprintf("envconfig.Process: error %s for fieldname %s: has type %s and tag %s", e.Message, e.FieldName, e.TypeName, e.Tag)
 }
 
-// // Loremizer is a type that wants
-// // lorem to generate a value based on the kind returned by
-// // LoremLike, and then passed into LoremFill
-// type Loremizer interface {
-// 	// LoremSimilarTo returns a type that you want generated for you
-// 	// to be passed in to LoremFill
-// 	// if you return reflect.Invalid LoremFill will be called with nil
-// 	// and you have to fill yourself
-// 	LoremSimilarTo() reflect.Kind
-// 	// LoremFill fill youself with the provided value based on
-// 	// the value returned by LoremSimilarTo
-// 	LoremFill(tag string, val interface{}) error
-// }
+// Unwrap returns the underlying error, so errors.Is(err, ErrNotUnique) works
+func (e *ParseError) Unwrap() error {
+	return e.err
+}
+
+// Filler is for types wanting to do their own loremizing with
+// the generator doing the filling, so the values they make are
+// reproducible with WithSeed
+type Filler interface {
+	// LoremFill is given the generator and the parsed
+	// tag on that field, and fills itself in
+	LoremFill(g *Generator, spec Spec) error
+}
 
 // Decoder is for types wanting to do their own loremizing
 // we assume clients can do their own random numbers
@@ -68,22 +70,42 @@ func extractSliceSize(tag string) (int, int, string, error) {
 	return 0, 0, tag, errors.New("didnt match regex")
 }
 
+var timeType = reflect.TypeOf(time.Time{})
+
 // this will handle everything
-func fillRec(loremTag string, field reflect.Value) error {
+func (g *Generator) fillRec(path, alias, loremTag string, field reflect.Value) error {
 
 	if !field.CanSet() || loremTag == "-" {
 		// ignore this field
 		return nil
 	}
-	// check for Loremizer
+	// registered types come before everything else
+	if ok, err := g.fillRegistered(loremTag, field); ok {
+		return err
+	}
+	// check for Filler, then Decoder
+	if filler := fillerFrom(field); filler != nil {
+		return filler.LoremFill(g, ParseSpec(loremTag))
+	}
 	decoder := decoderFrom(field)
 	if decoder != nil {
-		str, err := stringFromTag(loremTag)
+		str, err := g.stringFromTag(loremTag)
 		if err != nil {
 			return err
 		}
 		return decoder.LoremDecode(loremTag, str)
 	}
+	// types that can unmarshal themselves are only given
+	// a value when there is a tag saying what it should be
+	if loremTag != "" {
+		if unmarshaler := unmarshalerFrom(field); unmarshaler != nil {
+			str, err := g.stringFromTag(loremTag)
+			if err != nil {
+				return err
+			}
+			return unmarshaler.LoremDecode(loremTag, str)
+		}
+	}
 
 	// check for pointer first
 	typ := field.Type()
@@ -95,16 +117,31 @@ func fillRec(loremTag string, field reflect.Value) error {
 		field = field.Elem()
 	}
 
+	if ok, err := g.fillSQL(path, alias, loremTag, field); ok {
+		return err
+	}
+
+	if typ == timeType {
+		field.Set(reflect.ValueOf(g.Time()))
+		return nil
+	}
+
+	// custom kinds fill the whole field, except for slices
+	// where they apply to each entry like the built in kinds
+	if fn, args, ok := customKind(loremTag); ok && field.Kind() != reflect.Slice {
+		v, err := fn(g, args)
+		if err != nil {
+			return err
+		}
+		return setValue(field, v)
+	}
+
 	switch field.Kind() {
 	case reflect.Struct:
 		// call fillRec on each field
 		//todo: field.Anonymous
-		for i := 0; i < field.NumField(); i++ {
-			subField := field.Field(i)
-			err := fillRec(typ.Field(i).Tag.Get("lorem"), subField)
-			if err != nil {
-				return err
-			}
+		if err := g.fillStruct(path, alias, field); err != nil {
+			return err
 		}
 	case reflect.Slice:
 		// init slice, call fillRec on each slice entry
@@ -115,19 +152,27 @@ func fillRec(loremTag string, field reflect.Value) error {
 			max = 10
 		}
 
-		size := IntRange(min, max)
+		size := g.IntRange(min, max)
 		sl := reflect.MakeSlice(typ, size, size)
 		for i := 0; i < size; i++ {
 			sliceIndex := sl.Index(i)
-			err := fillRec(tag, sliceIndex)
+			err := g.fillRec(path, alias, tag, sliceIndex)
 			if err != nil {
 				return err
 			}
 		}
 		field.Set(sl)
+	case reflect.Map:
+		// maps are only filled when the tag
+		// says how many entries they should have
+		min, max, tag, err := extractSliceSize(loremTag)
+		if err != nil {
+			return nil
+		}
+		return g.fillMap(path, alias, min, max, tag, field)
 	default:
 		// handle simple type
-		err := processField(loremTag, field)
+		err := g.processField(loremTag, field)
 		if err != nil {
 			return err
 		}
@@ -137,7 +182,12 @@ func fillRec(loremTag string, field reflect.Value) error {
 
 // Fill will fill in the structure with random stuff
 // using lorme ipsum for strings
-func Fill(spec interface{}) error {
+func Fill(spec interface{}, opts ...Option) error {
+	return NewGenerator(opts...).Fill(spec)
+}
+
+// Fill will fill in the structure using the generator's configuration
+func (g *Generator) Fill(spec interface{}) error {
 	// must be a struct pointer
 	value := reflect.ValueOf(spec)
 	if value.Kind() != reflect.Ptr {
@@ -147,26 +197,256 @@ func Fill(spec interface{}) error {
 	if value.Kind() != reflect.Struct {
 		return errInvalidSpecification
 	}
-	typeOfValue := value.Type()
-	for i := 0; i < value.NumField(); i++ {
-		field := value.Field(i)
-		loremTag := typeOfValue.Field(i).Tag.Get("lorem")
-		err := fillRec(loremTag, field)
-		if err != nil {
-			return &ParseError{
-				Message:   err.Error(),
-				FieldName: typeOfValue.Field(i).Name,
-				TypeName:  field.Type().String(),
-				Tag:       loremTag,
+	if err := g.fillStruct("", "", value); err != nil {
+		var ferr *fieldError
+		if !errors.As(err, &ferr) {
+			return err
+		}
+		return &ParseError{
+			Message:   ferr.err.Error(),
+			FieldName: ferr.alias,
+			TypeName:  ferr.typ,
+			Tag:       ferr.tag,
+			err:       ferr.err,
+		}
+	}
+	return nil
+}
+
+// fieldError is an error filling the field at alias (its path using the
+// WithFieldNames names), however deeply nested, for Fill's ParseError
+type fieldError struct {
+	alias string
+	typ   string
+	tag   string
+	err   error
+}
+
+func (e *fieldError) Error() string {
+	return e.err.Error()
+}
+
+func (e *fieldError) Unwrap() error {
+	return e.err
+}
+
+// fieldError returns err as the error of the ith field of typ,
+// unless it already is a nested field's error
+func (g *Generator) fieldError(typ reflect.Type, plan *structPlan, i int, err error) error {
+	var ferr *fieldError
+	if errors.As(err, &ferr) {
+		return err
+	}
+	sf := typ.Field(i)
+	return &fieldError{
+		alias: plan.aliases[i],
+		typ:   sf.Type.String(),
+		tag:   g.fieldTag(typ, plan.paths[i], plan.aliases[i], sf),
+		err:   err,
+	}
+}
+
+// structPlan is everything worked out from a struct type's tags,
+// done once per type and path and reused for every value filled
+type structPlan struct {
+	tags  []string
+	paths []string
+	// paths using the fields' names from WithFieldNames
+	aliases []string
+	skip    []bool
+	unique  []bool
+	refs    []*fieldRefs
+	order   []int
+	// fields that can be left out in sparse fills
+	optional []bool
+	// fields filled from their validate tag
+	validate []*validateRules
+
+	// the field at fault if the tags can't be used
+	errIndex int
+	err      error
+}
+
+// planKey is a struct type and its path, which with WithFieldNames can
+// have a different alias for the same Go path under different roots
+type planKey struct {
+	typ   reflect.Type
+	path  string
+	alias string
+}
+
+// planFor returns the plan for filling the struct typ at path,
+// named alias using the WithFieldNames names
+func (g *Generator) planFor(path, alias string, typ reflect.Type) *structPlan {
+	key := planKey{typ, path, alias}
+	g.plansMu.Lock()
+	plan, ok := g.plans[key]
+	g.plansMu.Unlock()
+	if ok {
+		return plan
+	}
+
+	plan = &structPlan{
+		tags:     make([]string, typ.NumField()),
+		paths:    make([]string, typ.NumField()),
+		aliases:  make([]string, typ.NumField()),
+		skip:     make([]bool, typ.NumField()),
+		unique:   make([]bool, typ.NumField()),
+		validate: make([]*validateRules, typ.NumField()),
+	}
+	names := make([]string, typ.NumField())
+	untimed := make([]bool, typ.NumField())
+	for i := range plan.tags {
+		sf := typ.Field(i)
+		names[i] = g.fieldName(sf)
+		plan.paths[i] = joinPath(path, sf.Name)
+		plan.aliases[i] = joinPath(alias, names[i])
+		plan.skip[i] = g.ignored(sf)
+		tag, explicit := g.explicitTag(typ, plan.paths[i], plan.aliases[i], sf)
+		if !explicit {
+			// validate tags come before inference
+			if plan.validate[i] = g.validateRules(sf); plan.validate[i] == nil {
+				tag = g.inferTag(sf)
 			}
+			untimed[i] = tag == "" && plan.validate[i] == nil && len(g.inferRules) == 0 &&
+				(sf.Type == timeType || sf.Type == reflect.PtrTo(timeType))
+		}
+		plan.tags[i], plan.unique[i] = parseUnique(tag)
+	}
+	plan.order, plan.refs, plan.errIndex, plan.err = fieldOrder(typ, names, plan.tags)
+	// untagged times are left alone, as they always have been, unless
+	// inference is on or another field's tag refers to them
+	for _, r := range plan.refs {
+		if r == nil {
+			continue
 		}
+		for _, name := range r.names() {
+			if sf, ok := typ.FieldByName(name); ok && len(sf.Index) == 1 {
+				untimed[sf.Index[0]] = false
+			}
+		}
+	}
+	for i := range untimed {
+		plan.skip[i] = plan.skip[i] || untimed[i]
 	}
+	plan.optional = g.optionalFields(typ, plan.refs)
+
+	g.plansMu.Lock()
+	if g.plans == nil {
+		g.plans = map[planKey]*structPlan{}
+	}
+	g.plans[key] = plan
+	g.plansMu.Unlock()
+	return plan
+}
+
+// FillValue fills the value ptr points to, which can be of any type a
+// struct field can be (*[]T, *map[K]V, *string, *int and so on), using
+// tag as if it was the field's lorem tag. Maps are given between 1 and
+// 10 entries when the tag doesn't give a size.
+func FillValue(ptr interface{}, tag string, opts ...Option) error {
+	return NewGenerator(opts...).FillValue(ptr, tag)
+}
+
+// FillValue fills the value ptr points to using the generator's configuration
+func (g *Generator) FillValue(ptr interface{}, tag string) error {
+	value := reflect.ValueOf(ptr)
+	if value.Kind() != reflect.Ptr || value.IsNil() {
+		return errInvalidValue
+	}
+	return g.fillValue(value.Elem(), tag)
+}
+
+// fillValue fills v, which can be of any type, using tag. Maps are
+// given between 1 and 10 entries when the tag doesn't give a size.
+func (g *Generator) fillValue(v reflect.Value, tag string) error {
+	if v.Kind() == reflect.Map {
+		if _, _, _, err := extractSliceSize(tag); err != nil {
+			tag = "[1,10]" + tag
+		}
+	}
+	return g.fillRec("", "", tag, v)
+}
+
+// fillStruct fills each field of the struct value at path, filling fields
+// that are referred to by other fields' tags first. If it fails the
+// error says which field failed, as a *fieldError.
+func (g *Generator) fillStruct(path, alias string, value reflect.Value) error {
+	typ := value.Type()
+	plan := g.planFor(path, alias, typ)
+	if plan.err != nil {
+		return g.fieldError(typ, plan, plan.errIndex, plan.err)
+	}
+
+	var err error
+	for _, i := range plan.order {
+		i := i
+		if plan.skip[i] {
+			continue
+		}
+		switch g.partial(plan.paths[i], plan.aliases[i], value.Field(i)) {
+		case skipField:
+			continue
+		case descendField:
+			if err := g.descend(plan.paths[i], plan.aliases[i], value.Field(i)); err != nil {
+				return g.fieldError(typ, plan, i, err)
+			}
+			continue
+		}
+		if plan.optional[i] && g.absent() {
+			continue
+		}
+		fill := func() error {
+			if plan.validate[i] != nil {
+				return g.fillValidated(plan.paths[i], plan.aliases[i], plan.validate[i], value.Field(i))
+			}
+			if plan.refs[i] != nil {
+				return g.fillFromRefs(plan.tags[i], plan.refs[i], value.Field(i), value)
+			}
+			return g.fillRec(plan.paths[i], plan.aliases[i], plan.tags[i], value.Field(i))
+		}
+		if plan.unique[i] {
+			err = g.fillUnique(typ, i, value.Field(i), fill)
+		} else {
+			err = fill()
+		}
+		if err != nil {
+			return g.fieldError(typ, plan, i, err)
+		}
+	}
+	return nil
+}
+
+// fillMap sets field to a new map of between min and max entries, with
+// keys filled as if they were untagged and values filled using tag
+func (g *Generator) fillMap(path, alias string, min, max int, tag string, field reflect.Value) error {
+	typ := field.Type()
+	size := g.IntRange(min, max)
+	m := reflect.MakeMapWithSize(typ, size)
+	// keys can repeat, so give up on ever
+	// getting size entries eventually
+	for tries := 0; m.Len() < size && tries < size*10; tries++ {
+		key := reflect.New(typ.Key()).Elem()
+		if err := g.fillRec(path, alias, "", key); err != nil {
+			return err
+		}
+		value := reflect.New(typ.Elem()).Elem()
+		if err := g.fillRec(path, alias, tag, value); err != nil {
+			return err
+		}
+		m.SetMapIndex(key, value)
+	}
+	field.Set(m)
 	return nil
 }
 
-func stringFromTag(tag string) (string, error) {
+func (g *Generator) stringFromTag(tag string) (string, error) {
 	if tag == "" {
-		return Word(2, 10), nil
+		return g.Word(2, 10), nil
+	}
+	if text, ok := strings.CutPrefix(tag, "template,"); ok {
+		// templates can contain commas
+		return g.execTemplate(text)
 	}
 	args := strings.Split(tag, ",")
 	if args[0] == "" {
@@ -177,6 +457,19 @@ func stringFromTag(tag string) (string, error) {
 		return "", errors.New("must have another thing after comma")
 	}
 
+	if args[0] == "oneof" {
+		// choices are separated by |, so they can contain commas
+		return g.oneOf(strings.Split(strings.Join(args[1:], ","), "|")...), nil
+	}
+
+	if fn, args, ok := customKind(tag); ok {
+		v, err := fn(g, args)
+		if err != nil || v == nil {
+			return "", err
+		}
+		return fmt.Sprint(v), nil
+	}
+
 	var min = int64(2)
 	var max = int64(10)
 	if len(args) == 3 {
@@ -194,27 +487,37 @@ func stringFromTag(tag string) (string, error) {
 
 	switch args[0] {
 	case "word":
-		return Word(int(min), int(max)), nil
+		return g.Word(int(min), int(max)), nil
 	case "sentence":
-		return Sentence(int(min), int(max)), nil
+		return g.Sentence(int(min), int(max)), nil
 	case "paragraph":
-		return Paragraph(int(min), int(max)), nil
+		return g.Paragraph(int(min), int(max)), nil
 	case "url":
-		return URL(), nil
+		return g.URL(), nil
 	case "readablepath":
-		return ReadablePath(Sentence(int(min), int(max))), nil
+		return ReadablePath(g.Sentence(int(min), int(max))), nil
 	case "host":
-		return Host(), nil
+		return g.Host(), nil
 	case "email":
-		return Email(), nil
+		return g.Email(), nil
 	case "uuid":
-		return uuid.NewV4().String(), nil
+		return g.UUID(), nil
+	case "firstname":
+		return g.FirstName(), nil
+	case "lastname":
+		return g.LastName(), nil
+	case "name":
+		return g.Name(), nil
+	case "phone":
+		return g.Phone(), nil
+	case "time":
+		return g.Time().Format(time.RFC3339), nil
 	default:
 		return "", nil
 	}
 }
 
-func processField(tag string, field reflect.Value) error {
+func (g *Generator) processField(tag string, field reflect.Value) error {
 	typ := field.Type()
 
 	// decoder := decoderFrom(field)
@@ -234,7 +537,7 @@ func processField(tag string, field reflect.Value) error {
 	// no lorem tag specified, use default for everything
 	switch typ.Kind() {
 	case reflect.String:
-		str, err := stringFromTag(tag)
+		str, err := g.stringFromTag(tag)
 		if err != nil {
 			return err
 		}
@@ -286,37 +589,68 @@ func processField(tag string, field reflect.Value) error {
 		// 	}
 		// }
 	case reflect.Int, reflect.Int64:
-		field.SetInt(int64(rand.Int63()))
+		field.SetInt(int64(g.rand.Int63()))
 	case reflect.Int32:
-		field.SetInt(int64(rand.Int31()))
+		field.SetInt(int64(g.rand.Int31()))
 	case reflect.Int8:
-		field.SetInt(int64(IntRange(0, math.MaxInt8)))
+		field.SetInt(int64(g.IntRange(0, math.MaxInt8)))
 	case reflect.Int16:
-		field.SetInt(int64(IntRange(0, math.MaxInt16)))
+		field.SetInt(int64(g.IntRange(0, math.MaxInt16)))
 	case reflect.Uint32:
-		field.SetUint(uint64(rand.Uint32()))
+		field.SetUint(uint64(g.rand.Uint32()))
 	case reflect.Uint, reflect.Uint64:
-		field.SetUint(uint64(rand.Int63()))
+		field.SetUint(uint64(g.rand.Int63()))
 	case reflect.Uint8:
-		field.SetUint(uint64(IntRange(0, math.MaxUint8)))
+		field.SetUint(uint64(g.IntRange(0, math.MaxUint8)))
 	case reflect.Uint16:
-		field.SetUint(uint64(IntRange(0, math.MaxUint16)))
+		field.SetUint(uint64(g.IntRange(0, math.MaxUint16)))
 	case reflect.Bool:
 		// see if we can parse the bool
 		if b, err := strconv.ParseBool(tag); err == nil {
 			field.SetBool(b)
 		} else {
-			field.SetBool(rand.Int()%2 == 0)
+			field.SetBool(g.rand.Int()%2 == 0)
 		}
 	case reflect.Float32:
-		field.SetFloat(float64(rand.Float32()))
+		field.SetFloat(float64(g.rand.Float32()))
 	case reflect.Float64:
-		field.SetFloat(rand.Float64())
+		field.SetFloat(g.rand.Float64())
 	default:
 	}
 	return nil
 }
 
+var (
+	fillerType  = reflect.TypeOf((*Filler)(nil)).Elem()
+	decoderType = reflect.TypeOf((*Decoder)(nil)).Elem()
+)
+
+func fillerFrom(field reflect.Value) Filler {
+	// a nil pointer can't fill itself, so make one first
+	if field.Kind() == reflect.Ptr && field.IsNil() && field.CanSet() && field.Type().Implements(fillerType) {
+		field.Set(reflect.New(field.Type().Elem()))
+	}
+
+	if field.CanInterface() {
+		filler, ok := field.Interface().(Filler)
+		if ok {
+			return filler
+		}
+	}
+
+	// also check if pointer-to-type implements Filler,
+	// and we can get a pointer to our field
+	if field.CanAddr() {
+		field = field.Addr()
+		filler, ok := field.Interface().(Filler)
+		if ok {
+			return filler
+		}
+	}
+
+	return nil
+}
+
 func decoderFrom(field reflect.Value) Decoder {
 	if field.CanInterface() {
 		dec, ok := field.Interface().(Decoder)
@@ -698,3 +698,114 @@ func TestBoolOverride(t *testing.T) {
 		t.Errorf("BoolStr.False: expected %t, got %t", false, bb.False)
 	}
 }
+
+type StructWithFillers struct {
+	Sub        SubStructLikeRange  `lorem:"word,3,5"`
+	SubPointer *SubStructLikeRange `lorem:"literal,7"`
+}
+
+type SubStructLikeRange struct {
+	spec  Spec
+	words []string
+}
+
+func (s *SubStructLikeRange) LoremFill(g *Generator, spec Spec) error {
+	s.spec = spec
+	for i := 0; i < spec.Int(0, 1); i++ {
+		s.words = append(s.words, g.Word(2, 4))
+	}
+	return nil
+}
+
+func TestStructWithFillers(t *testing.T) {
+	var ss StructWithFillers
+	if err := Fill(&ss, WithSeed(5)); err != nil {
+		t.Error(err.Error())
+	}
+
+	if ss.Sub.spec.Kind != "word" {
+		t.Errorf("Sub.spec.Kind: expected %s, got %s", "word", ss.Sub.spec.Kind)
+	}
+	if len(ss.Sub.words) != 3 {
+		t.Errorf("Sub.words: expected %d words, got %d", 3, len(ss.Sub.words))
+	}
+	if ss.SubPointer == nil {
+		t.Fatal("SubPointer: expected pointer to not be nil")
+	}
+	if len(ss.SubPointer.words) != 7 {
+		t.Errorf("SubPointer.words: expected %d words, got %d", 7, len(ss.SubPointer.words))
+	}
+
+	var again StructWithFillers
+	if err := Fill(&again, WithSeed(5)); err != nil {
+		t.Error(err.Error())
+	}
+	if strings.Join(again.Sub.words, " ") != strings.Join(ss.Sub.words, " ") {
+		t.Errorf("Sub.words: expected %v, got %v", ss.Sub.words, again.Sub.words)
+	}
+}
+
+func TestFillValue(t *testing.T) {
+	var sentences []string
+	if err := FillValue(&sentences, "[3,3]sentence,2,4"); err != nil {
+		t.Error(err.Error())
+	}
+	if len(sentences) != 3 {
+		t.Errorf("sentences: expected %d entries, got %d", 3, len(sentences))
+	}
+	for _, s := range sentences {
+		if !strings.HasSuffix(s, ".") {
+			t.Errorf("sentences: expected sentence, got %s", s)
+		}
+	}
+
+	var ids map[string]int
+	if err := FillValue(&ids, "[2,2]"); err != nil {
+		t.Error(err.Error())
+	}
+	if len(ids) != 2 {
+		t.Errorf("ids: expected %d entries, got %d", 2, len(ids))
+	}
+
+	var emails map[int]string
+	if err := FillValue(&emails, "email"); err != nil {
+		t.Error(err.Error())
+	}
+	if len(emails) < 1 {
+		t.Errorf("emails: expected entries, got none")
+	}
+	for _, e := range emails {
+		if !strings.Contains(e, "@") {
+			t.Errorf("emails: expected email, got %s", e)
+		}
+	}
+
+	var str string
+	if err := NewGenerator(WithSeed(1)).FillValue(&str, ",hello"); err != nil {
+		t.Error(err.Error())
+	}
+	if str != "hello" {
+		t.Errorf("str: expected %s, got %s", "hello", str)
+	}
+
+	var n int
+	if err := FillValue(&n, ""); err != nil {
+		t.Error(err.Error())
+	}
+
+	var ss OtherStruct
+	if err := FillValue(&ss, ""); err != nil {
+		t.Error(err.Error())
+	}
+	if len(ss.SubWordWithRange) < 10 {
+		t.Errorf("SubWordWithRange: expected 9 < len(ss.SubWordWithRange) < 12, got %d", len(ss.SubWordWithRange))
+	}
+
+	if err := FillValue(str, ""); err != errInvalidValue {
+		t.Errorf("Expected %v, got %v", errInvalidValue, err)
+	}
+	var nilPointer *string
+	if err := FillValue(nilPointer, ""); err != errInvalidValue {
+		t.Errorf("Expected %v, got %v", errInvalidValue, err)
+	}
+}
@@ -0,0 +1,105 @@
+package lorem
+
+import (
+	"math/rand"
+	"sync"
+	"text/template"
+	"time"
+)
+
+// Generator fills structures according to its configuration.
+// A Generator with no options behaves exactly like the package level Fill.
+// A Generator made with WithSeed or WithRand is not safe for concurrent use.
+// Fields tagged unique are unique across everything a Generator fills.
+type Generator struct {
+	rand       randSource
+	now        func() time.Time
+	inferRules []InferRule
+	// words by length to use instead of the built in word list
+	corpus     map[int][]string
+	specs      map[string]string
+	nullRate   float64
+	sparseRate float64
+	validate   bool
+	onlyZero   bool
+	only       []string
+	exclude    []string
+	// struct tags naming fields, such as json
+	nameTags    []string
+	skipIgnored bool
+	// how Seed names columns and batches rows
+	sqlConfig SQLConfig
+
+	templatesMu sync.Mutex
+	templates   map[string]*template.Template
+
+	plansMu sync.Mutex
+	plans   map[planKey]*structPlan
+
+	uniqueTries int
+	uniqueMu    sync.Mutex
+	unique      map[uniqueKey]map[string]bool
+}
+
+// Option configures a Generator
+type Option func(*Generator)
+
+// std is used by the package level generators
+var std = NewGenerator()
+
+// NewGenerator returns a Generator configured with the given options
+func NewGenerator(opts ...Option) *Generator {
+	g := &Generator{
+		rand:        globalRand{},
+		now:         time.Now,
+		uniqueTries: defaultUniqueTries,
+	}
+	for _, opt := range opts {
+		opt(g)
+	}
+	return g
+}
+
+// WithSeed makes the generator produce the same values every time
+// for the same seed (together with WithNow for times)
+func WithSeed(seed int64) Option {
+	return WithRand(rand.New(rand.NewSource(seed)))
+}
+
+// WithRand makes the generator use r for all of its random numbers
+func WithRand(r *rand.Rand) Option {
+	return func(g *Generator) {
+		g.rand = r
+	}
+}
+
+// WithNow makes the generator produce times relative to now
+// instead of the current time
+func WithNow(now time.Time) Option {
+	return func(g *Generator) {
+		g.now = func() time.Time { return now }
+	}
+}
+
+// randSource is the part of *rand.Rand used by the generators
+type randSource interface {
+	Int() int
+	Int31() int32
+	Int63() int64
+	Int63n(n int64) int64
+	Uint32() uint32
+	Float32() float32
+	Float64() float64
+}
+
+// globalRand uses the package level math/rand functions,
+// which are safe for concurrent use
+type globalRand struct{}
+
+func (globalRand) Int() int             { return rand.Int() }
+func (globalRand) Int31() int32         { return rand.Int31() }
+func (globalRand) Int63() int64         { return rand.Int63() }
+func (globalRand) Int63n(n int64) int64 { return rand.Int63n(n) }
+func (globalRand) Uint32() uint32       { return rand.Uint32() }
+func (globalRand) Float32() float32     { return rand.Float32() }
+func (globalRand) Float64() float64     { return rand.Float64() }
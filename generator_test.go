@@ -0,0 +1,52 @@
+package lorem
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestWithSeed(t *testing.T) {
+	now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	var a, b, c SimpleStruct
+	if err := NewGenerator(WithSeed(42), WithNow(now)).Fill(&a); err != nil {
+		t.Error(err.Error())
+	}
+	if err := Fill(&b, WithSeed(42), WithNow(now)); err != nil {
+		t.Error(err.Error())
+	}
+	if err := Fill(&c, WithRand(rand.New(rand.NewSource(43))), WithNow(now)); err != nil {
+		t.Error(err.Error())
+	}
+
+	if !reflect.DeepEqual(a, b) {
+		t.Errorf("Expected the same seed to give the same values, got %+v and %+v", a, b)
+	}
+	if reflect.DeepEqual(a, c) {
+		t.Errorf("Expected different seeds to give different values, got %+v", a)
+	}
+}
+
+func TestGeneratorMethods(t *testing.T) {
+	now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	g1 := NewGenerator(WithSeed(1), WithNow(now))
+	g2 := NewGenerator(WithSeed(1), WithNow(now))
+
+	for i := 0; i < 10; i++ {
+		if a, b := g1.Paragraph(1, 3), g2.Paragraph(1, 3); a != b {
+			t.Errorf("Paragraph: expected %s, got %s", a, b)
+		}
+		if a, b := g1.Email(), g2.Email(); a != b {
+			t.Errorf("Email: expected %s, got %s", a, b)
+		}
+		if a, b := g1.UUID(), g2.UUID(); a != b {
+			t.Errorf("UUID: expected %s, got %s", a, b)
+		}
+		if tm := g1.Time(); tm.After(now) || tm.Before(now.AddDate(-1, 0, 0)) {
+			t.Errorf("Time: expected time in the year before %s, got %s", now, tm)
+		}
+		g2.Time()
+	}
+}
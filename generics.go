@@ -0,0 +1,52 @@
+package lorem
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// New returns a newly filled T, which can be any type the struct
+// fields of Fill can be, so New[[]string]() makes a slice of words
+// and New[map[string]int]() makes a map of random entries
+func New[T any](opts ...Option) (T, error) {
+	var v T
+	err := NewGenerator(opts...).fillValue(reflect.ValueOf(&v).Elem(), "")
+	return v, err
+}
+
+// MustNew is like New but panics if T can't be filled
+func MustNew[T any](opts ...Option) T {
+	v, err := New[T](opts...)
+	if err != nil {
+		panic("lorem: MustNew " + err.Error())
+	}
+	return v
+}
+
+// Slice returns between min (inclusive) and max (exclusive) newly filled
+// values of T, or exactly min if they are equal. It returns an error if min
+// is negative or more than max.
+func Slice[T any](min, max int, opts ...Option) ([]T, error) {
+	if min < 0 || max < min {
+		return nil, fmt.Errorf("lorem: Slice needs 0 <= min <= max, got %d and %d", min, max)
+	}
+	var v []T
+	err := NewGenerator(opts...).fillValue(reflect.ValueOf(&v).Elem(), fmt.Sprintf("[%d,%d]", min, max))
+	return v, err
+}
+
+// OneOf returns one of vals picked at random, or the zero value of T if
+// there are none. It picks with the package level generator, so it isn't
+// affected by WithSeed; use OneOfWith for that.
+func OneOf[T any](vals ...T) T {
+	return OneOfWith(std, vals...)
+}
+
+// OneOfWith is like OneOf but picks with g
+func OneOfWith[T any](g *Generator, vals ...T) T {
+	if len(vals) == 0 {
+		var zero T
+		return zero
+	}
+	return vals[g.IntRange(0, len(vals))]
+}
@@ -0,0 +1,126 @@
+package lorem
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNew(t *testing.T) {
+	ss, err := New[SimpleStruct]()
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if ss.Word == "" {
+		t.Errorf("Word: expected string not empty, got %s", ss.Word)
+	}
+
+	words := MustNew[[]string]()
+	if len(words) < 1 || len(words) > 10 {
+		t.Errorf("Expected 1 to 10 words, got %d", len(words))
+	}
+	for _, w := range words {
+		if w == "" {
+			t.Errorf("Expected word not empty")
+		}
+	}
+
+	m := MustNew[map[string]int]()
+	if len(m) < 1 || len(m) > 10 {
+		t.Errorf("Expected 1 to 10 entries, got %d", len(m))
+	}
+
+	p := MustNew[*OtherStruct]()
+	if p == nil || !strings.Contains(*p.SubEmailPointer, "@") {
+		t.Errorf("Expected filled pointer, got %v", p)
+	}
+
+	if tm := MustNew[time.Time](); tm.IsZero() {
+		t.Errorf("Expected time to be set")
+	}
+
+	if n := MustNew[int8](); n < 0 {
+		t.Errorf("Expected positive int8, got %d", n)
+	}
+}
+
+func TestNewErrors(t *testing.T) {
+	if _, err := New[Unfillable](); err == nil {
+		t.Error("Expected error, got nil")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected MustNew to panic")
+		}
+	}()
+	MustNew[Unfillable]()
+}
+
+func TestSlice(t *testing.T) {
+	users, err := Slice[UniqueUser](5, 5)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if len(users) != 5 {
+		t.Errorf("Expected %d users, got %d", 5, len(users))
+	}
+
+	emails, err := Slice[*string](2, 4)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if len(emails) < 2 || len(emails) >= 4 {
+		t.Errorf("Expected 2 or 3 entries, got %d", len(emails))
+	}
+
+	for _, bounds := range [][2]int{{-1, 3}, {4, 2}, {-3, -1}} {
+		if _, err := Slice[string](bounds[0], bounds[1]); err == nil {
+			t.Errorf("Expected an error for min %d and max %d", bounds[0], bounds[1])
+		}
+	}
+	if empty, err := Slice[string](0, 0); err != nil || len(empty) != 0 {
+		t.Errorf("Expected no entries, got %v and %v", empty, err)
+	}
+}
+
+func TestStructWithMapSize(t *testing.T) {
+	var ss struct {
+		Map   map[string]string `lorem:"[3,3]email"`
+		Bools map[bool]int      `lorem:"[5,6]"`
+	}
+	if err := Fill(&ss); err != nil {
+		t.Fatal(err.Error())
+	}
+	if len(ss.Map) != 3 {
+		t.Errorf("Map: expected %d entries, got %d", 3, len(ss.Map))
+	}
+	for _, v := range ss.Map {
+		if !strings.Contains(v, "@") {
+			t.Errorf("Map: expected email, got %s", v)
+		}
+	}
+	if len(ss.Bools) == 0 || len(ss.Bools) > 2 {
+		t.Errorf("Bools: expected 1 or 2 entries, got %d", len(ss.Bools))
+	}
+}
+
+func TestOneOf(t *testing.T) {
+	seen := map[int]bool{}
+	for i := 0; i < 100; i++ {
+		seen[OneOf(1, 2, 3)] = true
+	}
+	if len(seen) != 3 {
+		t.Errorf("Expected 1, 2 and 3, got %v", seen)
+	}
+	if OneOf[string]() != "" {
+		t.Errorf("Expected empty string")
+	}
+
+	a, b := NewGenerator(WithSeed(7)), NewGenerator(WithSeed(7))
+	for i := 0; i < 20; i++ {
+		if x, y := OneOfWith(a, 1, 2, 3, 4, 5), OneOfWith(b, 1, 2, 3, 4, 5); x != y {
+			t.Fatalf("Expected the same picks for the same seed, got %d and %d", x, y)
+		}
+	}
+}
@@ -0,0 +1,5 @@
+module github.com/axiomzen/golorem
+
+go 1.23
+
+require github.com/twinj/uuid v1.0.0
@@ -0,0 +1,109 @@
+package lorem
+
+import (
+	"reflect"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// InferRule maps a field name to the lorem tag used for that field
+// when it has no lorem tag of its own.
+type InferRule struct {
+	// Pattern is matched against the lower cased field name (and json name)
+	// with any '_' or '-' removed, so CreatedAt, created_at and created-at
+	// are all seen as "createdat". A match only counts if it starts where
+	// a word of the name does, at a '_', '-' or change of case, so `time$`
+	// matches StartTime and start_time but not Runtime.
+	Pattern *regexp.Regexp
+	// Tag is used exactly as if it had been written as `lorem:"<Tag>"`
+	Tag string
+}
+
+// DefaultInferRules is the rule table used by WithInference when no
+// rules are given. Rules are tried in order and the first match wins,
+// so copy it and put your own rules first to override an entry.
+var DefaultInferRules = []InferRule{
+	{regexp.MustCompile(`e?mail(address)?$`), "email"},
+	{regexp.MustCompile(`(url|uri|website|homepage|link)$`), "url"},
+	{regexp.MustCompile(`(host|hostname|domain)$`), "host"},
+	{regexp.MustCompile(`(uuid|guid)$`), "uuid"},
+	{regexp.MustCompile(`(firstname|givenname|forename)$`), "firstname"},
+	{regexp.MustCompile(`(lastname|surname|familyname)$`), "lastname"},
+	{regexp.MustCompile(`^(full)?name$`), "name"},
+	{regexp.MustCompile(`(phone|mobile|telephone)$|^(tel|fax|cell)$`), "phone"},
+	{regexp.MustCompile(`(slug|path)$`), "readablepath"},
+	{regexp.MustCompile(`(title|subject|headline|caption)$`), "sentence,3,8"},
+	{regexp.MustCompile(`(description|summary|body|content|bio|text|comment|notes?)$`), "paragraph,1,3"},
+	{regexp.MustCompile(`((created|updated|deleted|modified|published|expires|started|ended)(at|on)|date|time|timestamp)$`), "time"},
+}
+
+// WithInference turns on field name inference: fields without a lorem tag
+// are matched against rules by name, and the first matching rule provides
+// their tag. With no rules DefaultInferRules is used.
+func WithInference(rules ...InferRule) Option {
+	return func(g *Generator) {
+		if len(rules) == 0 {
+			rules = DefaultInferRules
+		}
+		g.inferRules = rules
+	}
+}
+
+// inferTag returns the tag of the first rule matching the field's name,
+// or "" if inference is turned off or nothing matches
+func (g *Generator) inferTag(sf reflect.StructField) string {
+	if len(g.inferRules) == 0 {
+		return ""
+	}
+	names := []string{sf.Name}
+	if json := strings.Split(sf.Tag.Get("json"), ",")[0]; json != "" && json != "-" {
+		names = append(names, json)
+	}
+	for _, rule := range g.inferRules {
+		for _, name := range names {
+			if rule.matches(name) {
+				return rule.Tag
+			}
+		}
+	}
+	return ""
+}
+
+// matches reports whether the rule's pattern matches the normalized
+// name, starting at the start of one of the name's words
+func (rule InferRule) matches(name string) bool {
+	starts := wordStarts(name)
+	for _, loc := range rule.Pattern.FindAllStringIndex(normalizeFieldName(name), -1) {
+		if starts[loc[0]] {
+			return true
+		}
+	}
+	return false
+}
+
+// wordStarts returns where the words of name start in its normalized
+// form, so CreatedAt and created_at give 0 and 7, and HTTPServer 0 and 4
+func wordStarts(name string) map[int]bool {
+	starts := map[int]bool{0: true}
+	runes := []rune(name)
+	n := 0
+	for i, r := range runes {
+		if r == '_' || r == '-' {
+			starts[n] = true
+			continue
+		}
+		if i > 0 && unicode.IsUpper(r) && (!unicode.IsUpper(runes[i-1]) ||
+			i+1 < len(runes) && unicode.IsLower(runes[i+1])) {
+			starts[n] = true
+		}
+		n += len(string(unicode.ToLower(r)))
+	}
+	return starts
+}
+
+var fieldNameReplacer = strings.NewReplacer("_", "", "-", "")
+
+func normalizeFieldName(name string) string {
+	return fieldNameReplacer.Replace(strings.ToLower(name))
+}
@@ -0,0 +1,151 @@
+package lorem
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+)
+
+type UntaggedStruct struct {
+	Email       string
+	HomePage    string
+	FirstName   string
+	LastName    string
+	Name        string
+	Phone       string
+	Slug        string
+	CreatedAt   time.Time
+	UpdatedAt   string
+	Contact     string `json:"contact_email"`
+	Description string `lorem:"word,10,11"`
+	Other       string
+}
+
+func TestInferenceOffByDefault(t *testing.T) {
+	var us UntaggedStruct
+	if err := Fill(&us); err != nil {
+		t.Error(err.Error())
+	}
+
+	if strings.Contains(us.Email, "@") {
+		t.Errorf("Email: expected a plain word, got %s", us.Email)
+	}
+}
+
+func TestInferenceDefaultRules(t *testing.T) {
+	var us UntaggedStruct
+	if err := Fill(&us, WithInference()); err != nil {
+		t.Error(err.Error())
+	}
+
+	if !strings.Contains(us.Email, "@") {
+		t.Errorf("Email: expected Email to contain '@', got %s", us.Email)
+	}
+	if !strings.HasPrefix(us.HomePage, "http://www.") {
+		t.Errorf("HomePage: expected url to start with http://www., got %s", us.HomePage)
+	}
+	if us.FirstName == "" || strings.Contains(us.FirstName, " ") {
+		t.Errorf("FirstName: expected a single name, got %s", us.FirstName)
+	}
+	if us.LastName == "" || strings.Contains(us.LastName, " ") {
+		t.Errorf("LastName: expected a single name, got %s", us.LastName)
+	}
+	if strings.Count(us.Name, " ") != 1 {
+		t.Errorf("Name: expected first and last name, got %s", us.Name)
+	}
+	if !strings.HasPrefix(us.Phone, "(") {
+		t.Errorf("Phone: expected phone number, got %s", us.Phone)
+	}
+	if strings.Contains(us.Slug, " ") || strings.Contains(us.Slug, ".") {
+		t.Errorf("Slug: expected readable path, got %s", us.Slug)
+	}
+	if us.CreatedAt.IsZero() {
+		t.Errorf("CreatedAt: expected time to be set")
+	}
+	if _, err := time.Parse(time.RFC3339, us.UpdatedAt); err != nil {
+		t.Errorf("UpdatedAt: expected RFC3339 time, got %s", us.UpdatedAt)
+	}
+	if !strings.Contains(us.Contact, "@") {
+		t.Errorf("Contact: expected json name to infer email, got %s", us.Contact)
+	}
+	if len(us.Description) < 10 || len(us.Description) > 11 {
+		t.Errorf("Description: expected lorem tag to win, got %s", us.Description)
+	}
+	if us.Other == "" {
+		t.Errorf("Other: expected string not empty, got %s", us.Other)
+	}
+}
+
+func TestInferenceWordBoundaries(t *testing.T) {
+	var ws struct {
+		Candidate string
+		Runtime   string
+		Context   string
+		Somebody  string
+		Filepath  string
+		StartTime string
+		FilePath  string `json:"file_path"`
+		Path      string `json:"avatar-path"`
+		AvatarURL string
+	}
+	if err := Fill(&ws, WithInference()); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	word := regexp.MustCompile(`^[a-z]+$`)
+	for name, v := range map[string]string{
+		"Candidate": ws.Candidate,
+		"Runtime":   ws.Runtime,
+		"Context":   ws.Context,
+		"Somebody":  ws.Somebody,
+		"Filepath":  ws.Filepath,
+	} {
+		if !word.MatchString(v) {
+			t.Errorf("%s: expected a plain word for a rule matching inside a word, got %q", name, v)
+		}
+	}
+	if _, err := time.Parse(time.RFC3339, ws.StartTime); err != nil {
+		t.Errorf("StartTime: expected RFC3339 time, got %s", ws.StartTime)
+	}
+	if word.MatchString(ws.FilePath) || word.MatchString(ws.Path) {
+		t.Errorf("expected readable paths, got %q and %q", ws.FilePath, ws.Path)
+	}
+	if !strings.HasPrefix(ws.AvatarURL, "http://www.") {
+		t.Errorf("AvatarURL: expected url, got %s", ws.AvatarURL)
+	}
+}
+
+func TestInferenceCustomRules(t *testing.T) {
+	var us UntaggedStruct
+	rules := append([]InferRule{{regexp.MustCompile(`^other$`), ",fixed"}}, DefaultInferRules...)
+	if err := Fill(&us, WithInference(rules...)); err != nil {
+		t.Error(err.Error())
+	}
+
+	if us.Other != "fixed" {
+		t.Errorf("Other: expected %s, got %s", "fixed", us.Other)
+	}
+	if !strings.Contains(us.Email, "@") {
+		t.Errorf("Email: expected Email to contain '@', got %s", us.Email)
+	}
+}
+
+func TestInferenceNested(t *testing.T) {
+	var ss struct {
+		User  UntaggedStruct
+		Users []UntaggedStruct `lorem:"[2,3]"`
+	}
+	if err := Fill(&ss, WithInference()); err != nil {
+		t.Error(err.Error())
+	}
+
+	if !strings.Contains(ss.User.Email, "@") {
+		t.Errorf("User.Email: expected Email to contain '@', got %s", ss.User.Email)
+	}
+	for _, u := range ss.Users {
+		if !strings.Contains(u.Email, "@") {
+			t.Errorf("Users.Email: expected Email to contain '@', got %s", u.Email)
+		}
+	}
+}
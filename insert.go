@@ -0,0 +1,368 @@
+package lorem
+
+import (
+	"database/sql/driver"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	valuerType = reflect.TypeOf((*driver.Valuer)(nil)).Elem()
+
+	errInvalidStruct = errors.New("must provide a struct or struct pointer")
+	errNoColumns     = errors.New("struct has no columns")
+)
+
+// Dialect is the flavour of SQL to quote and escape for
+type Dialect int
+
+// The dialects
+const (
+	Postgres Dialect = iota
+	MySQL
+	SQLite
+)
+
+// defaultBatch is how many rows an INSERT has by default
+const defaultBatch = 100
+
+// SQLConfig configures WriteInserts, WriteCopy and Seed. The zero value
+// is Postgres with columns named by db tags, 100 rows per INSERT.
+type SQLConfig struct {
+	// Dialect is the SQL to write
+	Dialect Dialect
+	// Tag is the struct tag naming columns, db if empty
+	Tag string
+	// Batch is how many rows each INSERT has, or each transaction
+	// of Seed, 100 if 0
+	Batch int
+}
+
+// batch returns how many rows to write or insert at a time
+func (cfg SQLConfig) batch() int {
+	if cfg.Batch <= 0 {
+		return defaultBatch
+	}
+	return cfg.Batch
+}
+
+// WriteInserts writes INSERT statements for n newly filled values of
+// proto's type, a struct, into table
+func WriteInserts(w io.Writer, table string, proto interface{}, n int, cfg SQLConfig, opts ...Option) error {
+	return NewGenerator(opts...).WriteInserts(w, table, proto, n, cfg)
+}
+
+// WriteInserts writes INSERT statements for n newly filled values of
+// proto's type, a struct, into table, with cfg.Batch rows per statement.
+//
+// Each exported field is a column named by its cfg.Tag tag, or its lower
+// cased name if it has none, except fields tagged "-". Embedded structs
+// add their fields, and fields that aren't a column type (structs other
+// than time.Time and driver.Valuer types, slices other than []byte,
+// maps and so on) are left out. Nil pointers and invalid sql.Null*
+// values are NULL.
+func (g *Generator) WriteInserts(w io.Writer, table string, proto interface{}, n int, cfg SQLConfig) error {
+	typ, cols, err := sqlColumns(proto, cfg.Tag)
+	if err != nil {
+		return err
+	}
+	batch := cfg.batch()
+
+	names := make([]string, len(cols))
+	for i, col := range cols {
+		names[i] = cfg.Dialect.quoteIdent(col.name)
+	}
+	insert := "INSERT INTO " + cfg.Dialect.quoteTable(table) + " (" + strings.Join(names, ", ") + ") VALUES\n"
+
+	literals := make([]string, len(cols))
+	for i := 0; i < n; i++ {
+		_, row, err := g.sqlRow(typ, cols)
+		if err != nil {
+			return err
+		}
+		for j, v := range row {
+			literals[j] = cfg.Dialect.literal(v)
+		}
+		var b strings.Builder
+		if i%batch == 0 {
+			b.WriteString(insert)
+		}
+		b.WriteString("\t(" + strings.Join(literals, ", ") + ")")
+		if i%batch == batch-1 || i == n-1 {
+			b.WriteString(";\n")
+		} else {
+			b.WriteString(",\n")
+		}
+		if _, err := io.WriteString(w, b.String()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteCopy writes a PostgreSQL COPY ... FROM stdin statement, as psql
+// runs it, with n newly filled values of proto's type, a struct
+func WriteCopy(w io.Writer, table string, proto interface{}, n int, cfg SQLConfig, opts ...Option) error {
+	return NewGenerator(opts...).WriteCopy(w, table, proto, n, cfg)
+}
+
+// WriteCopy writes a PostgreSQL COPY ... FROM stdin statement, as psql
+// runs it, with n newly filled values of proto's type, a struct, into
+// table. Columns are as for WriteInserts, and cfg.Dialect must be Postgres.
+func (g *Generator) WriteCopy(w io.Writer, table string, proto interface{}, n int, cfg SQLConfig) error {
+	if cfg.Dialect != Postgres {
+		return errors.New("COPY is only written for Postgres")
+	}
+	typ, cols, err := sqlColumns(proto, cfg.Tag)
+	if err != nil {
+		return err
+	}
+
+	names := make([]string, len(cols))
+	for i, col := range cols {
+		names[i] = Postgres.quoteIdent(col.name)
+	}
+	header := "COPY " + Postgres.quoteTable(table) + " (" + strings.Join(names, ", ") + ") FROM stdin;\n"
+	if _, err := io.WriteString(w, header); err != nil {
+		return err
+	}
+
+	fields := make([]string, len(cols))
+	for i := 0; i < n; i++ {
+		_, row, err := g.sqlRow(typ, cols)
+		if err != nil {
+			return err
+		}
+		for j, v := range row {
+			fields[j] = copyField(v)
+		}
+		if _, err := io.WriteString(w, strings.Join(fields, "\t")+"\n"); err != nil {
+			return err
+		}
+	}
+	_, err = io.WriteString(w, "\\.\n")
+	return err
+}
+
+// sqlColumn is a column and the index of its field
+type sqlColumn struct {
+	name  string
+	index []int
+}
+
+// sqlColumns returns the struct type of proto and its columns,
+// named by tag (db if empty)
+func sqlColumns(proto interface{}, tag string) (reflect.Type, []sqlColumn, error) {
+	typ := reflect.TypeOf(proto)
+	for typ != nil && typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	if typ == nil || typ.Kind() != reflect.Struct {
+		return nil, nil, errInvalidStruct
+	}
+	if tag == "" {
+		tag = "db"
+	}
+	cols := appendColumns(nil, typ, nil, tag)
+	if len(cols) == 0 {
+		return nil, nil, fmt.Errorf("%s: %w", typ, errNoColumns)
+	}
+	return typ, cols, nil
+}
+
+func appendColumns(cols []sqlColumn, typ reflect.Type, index []int, tag string) []sqlColumn {
+	for i := 0; i < typ.NumField(); i++ {
+		sf := typ.Field(i)
+		name, _, _ := strings.Cut(sf.Tag.Get(tag), ",")
+		if !sf.IsExported() || name == "-" {
+			continue
+		}
+		idx := append(append([]int{}, index...), i)
+		ft := sf.Type
+		if ft.Kind() == reflect.Ptr {
+			ft = ft.Elem()
+		}
+		if sf.Anonymous && name == "" && ft.Kind() == reflect.Struct && !isColumnType(ft) {
+			cols = appendColumns(cols, ft, idx, tag)
+			continue
+		}
+		if !isColumnType(ft) {
+			continue
+		}
+		if name == "" {
+			name = strings.ToLower(sf.Name)
+		}
+		cols = append(cols, sqlColumn{name: name, index: idx})
+	}
+	return cols
+}
+
+// isColumnType reports whether values of typ can be a column
+func isColumnType(typ reflect.Type) bool {
+	if typ.Implements(valuerType) || reflect.PtrTo(typ).Implements(valuerType) {
+		return true
+	}
+	switch typ.Kind() {
+	case reflect.Bool, reflect.String,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return typ == timeType || typ.Kind() == reflect.Slice && typ.Elem().Kind() == reflect.Uint8
+}
+
+// sqlRow fills a new value of typ and returns a pointer to it
+// and its columns as driver values
+func (g *Generator) sqlRow(typ reflect.Type, cols []sqlColumn) (reflect.Value, []driver.Value, error) {
+	v := reflect.New(typ)
+	if err := g.Fill(v.Interface()); err != nil {
+		return v, nil, err
+	}
+	row, err := columnValues(v.Elem(), cols)
+	return v, row, err
+}
+
+// columnValues returns the columns of the struct v as driver values,
+// with nil for nil pointers and slices
+func columnValues(v reflect.Value, cols []sqlColumn) ([]driver.Value, error) {
+	row := make([]driver.Value, len(cols))
+	for i, col := range cols {
+		field, ok := fieldByIndex(v, col.index)
+		if !ok || field.Kind() == reflect.Slice && field.IsNil() {
+			continue
+		}
+		arg := field.Interface()
+		if field.Kind() != reflect.Ptr && reflect.PtrTo(field.Type()).Implements(valuerType) {
+			// for Value methods on pointers
+			addr := reflect.New(field.Type())
+			addr.Elem().Set(field)
+			arg = addr.Interface()
+		}
+		var err error
+		if row[i], err = driver.DefaultParameterConverter.ConvertValue(arg); err != nil {
+			return nil, fmt.Errorf("%s: %w", col.name, err)
+		}
+	}
+	return row, nil
+}
+
+// fieldByIndex is like reflect.Value.FieldByIndex, returning false
+// instead of panicking at a nil embedded pointer
+func fieldByIndex(v reflect.Value, index []int) (reflect.Value, bool) {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				return reflect.Value{}, false
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v, true
+}
+
+// quoteIdent quotes a column or table name
+func (d Dialect) quoteIdent(name string) string {
+	if d == MySQL {
+		return "`" + strings.ReplaceAll(name, "`", "``") + "`"
+	}
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
+// quoteTable quotes a table name, which may be qualified by a schema
+// or database as in public.users
+func (d Dialect) quoteTable(table string) string {
+	parts := strings.Split(table, ".")
+	for i, part := range parts {
+		parts[i] = d.quoteIdent(part)
+	}
+	return strings.Join(parts, ".")
+}
+
+// literal returns v, a driver value, as a SQL literal
+func (d Dialect) literal(v driver.Value) string {
+	switch v := v.(type) {
+	case nil:
+		return "NULL"
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case float64:
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	case bool:
+		if d == SQLite {
+			if v {
+				return "1"
+			}
+			return "0"
+		}
+		return strings.ToUpper(strconv.FormatBool(v))
+	case []byte:
+		if d == Postgres {
+			return `'\x` + hex.EncodeToString(v) + `'`
+		}
+		return "X'" + hex.EncodeToString(v) + "'"
+	case time.Time:
+		return d.quoteString(d.formatTime(v))
+	case string:
+		return d.quoteString(v)
+	}
+	return d.quoteString(fmt.Sprint(v))
+}
+
+// quoteString quotes s as a string literal. MySQL treats backslashes as
+// escapes, while Postgres (with standard_conforming_strings, the default)
+// and SQLite only need quotes doubled.
+func (d Dialect) quoteString(s string) string {
+	if d != MySQL {
+		return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+	}
+	return "'" + strings.NewReplacer(
+		`\`, `\\`,
+		`'`, `\'`,
+		"\x00", `\0`,
+		"\n", `\n`,
+		"\r", `\r`,
+		"\x1a", `\Z`,
+	).Replace(s) + "'"
+}
+
+// formatTime formats t as each database reads it. MySQL's DATETIME has
+// no time zone, so times are written in UTC.
+func (d Dialect) formatTime(t time.Time) string {
+	if d == MySQL {
+		return t.UTC().Format("2006-01-02 15:04:05.999999")
+	}
+	return t.Format("2006-01-02 15:04:05.999999999-07:00")
+}
+
+// copyField returns v, a driver value, as a field of COPY's text format
+func copyField(v driver.Value) string {
+	switch v := v.(type) {
+	case nil:
+		return `\N`
+	case bool:
+		if v {
+			return "t"
+		}
+		return "f"
+	case []byte:
+		return `\\x` + hex.EncodeToString(v)
+	case time.Time:
+		return Postgres.formatTime(v)
+	case int64, float64:
+		return fmt.Sprint(v)
+	}
+	return strings.NewReplacer(
+		`\`, `\\`,
+		"\t", `\t`,
+		"\n", `\n`,
+		"\r", `\r`,
+	).Replace(fmt.Sprint(v))
+}
@@ -0,0 +1,159 @@
+package lorem
+
+import (
+	"bytes"
+	"database/sql"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+type Audit struct {
+	By string `db:"created_by" lorem:",ada"`
+}
+
+type InsertRow struct {
+	Audit
+	ID     int64          `db:"id" lorem:"-"`
+	Name   string         `db:"name" lorem:",it's a \\ \"test\"\tand\nmore"`
+	Bio    *string        `db:"bio" lorem:"-"`
+	Note   sql.NullString `db:"note" lorem:"-"`
+	Active bool           `lorem:"-"`
+	Data   []byte         `db:"data" lorem:"-"`
+	Seen   time.Time      `db:"seen" lorem:"-"`
+	Secret string         `db:"-"`
+	Tags   []string       `db:"tags"`
+	Friend *Audit         `db:"friend"`
+}
+
+func TestWriteInserts(t *testing.T) {
+	var b bytes.Buffer
+	if err := WriteInserts(&b, "public.items", InsertRow{}, 3, SQLConfig{Batch: 2}); err != nil {
+		t.Fatal(err.Error())
+	}
+	row := "('ada', 0, 'it''s a \\ \"test\"\tand\nmore', NULL, NULL, FALSE, NULL, '0001-01-01 00:00:00+00:00')"
+	want := `INSERT INTO "public"."items" ("created_by", "id", "name", "bio", "note", "active", "data", "seen") VALUES` + "\n" +
+		"\t" + row + ",\n" +
+		"\t" + row + ";\n" +
+		`INSERT INTO "public"."items" ("created_by", "id", "name", "bio", "note", "active", "data", "seen") VALUES` + "\n" +
+		"\t" + row + ";\n"
+	if b.String() != want {
+		t.Errorf("expected\n%s\ngot\n%s", want, b.String())
+	}
+
+	b.Reset()
+	if err := WriteInserts(&b, "items", &InsertRow{}, 1, SQLConfig{Dialect: MySQL}); err != nil {
+		t.Fatal(err.Error())
+	}
+	want = "INSERT INTO `items` (`created_by`, `id`, `name`, `bio`, `note`, `active`, `data`, `seen`) VALUES\n" +
+		"\t('ada', 0, 'it\\'s a \\\\ \"test\"\tand\\nmore', NULL, NULL, FALSE, NULL, '0001-01-01 00:00:00');\n"
+	if b.String() != want {
+		t.Errorf("expected\n%s\ngot\n%s", want, b.String())
+	}
+
+	b.Reset()
+	if err := WriteInserts(&b, "items", InsertRow{}, 0, SQLConfig{}); err != nil || b.Len() != 0 {
+		t.Errorf("expected nothing for no rows, got %q %v", b.String(), err)
+	}
+}
+
+func TestWriteCopy(t *testing.T) {
+	var b bytes.Buffer
+	if err := WriteCopy(&b, "items", InsertRow{}, 2, SQLConfig{}); err != nil {
+		t.Fatal(err.Error())
+	}
+	line := "ada\t0\tit's a \\\\ \"test\"\\tand\\nmore\t\\N\t\\N\tf\t\\N\t0001-01-01 00:00:00+00:00\n"
+	want := `COPY "items" ("created_by", "id", "name", "bio", "note", "active", "data", "seen") FROM stdin;` + "\n" +
+		line + line + "\\.\n"
+	if b.String() != want {
+		t.Errorf("expected\n%s\ngot\n%s", want, b.String())
+	}
+
+	if err := WriteCopy(&b, "items", InsertRow{}, 2, SQLConfig{Dialect: MySQL}); err == nil {
+		t.Error("expected an error for COPY in MySQL")
+	}
+}
+
+func TestSQLColumns(t *testing.T) {
+	type tagged struct {
+		Email string `sql:"email_address" db:"email"`
+		Name  string
+	}
+	_, cols, err := sqlColumns(tagged{}, "sql")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if len(cols) != 2 || cols[0].name != "email_address" || cols[1].name != "name" {
+		t.Errorf("expected columns named by the sql tag, got %v", cols)
+	}
+
+	if _, _, err := sqlColumns("text", ""); err == nil {
+		t.Error("expected an error for a value that isn't a struct")
+	}
+	type none struct {
+		Tags []string
+	}
+	if _, _, err := sqlColumns(none{}, ""); !errors.Is(err, errNoColumns) {
+		t.Errorf("expected errNoColumns, got %v", err)
+	}
+}
+
+func TestLiterals(t *testing.T) {
+	at := time.Date(2020, 1, 2, 3, 4, 5, 600000000, time.FixedZone("", -8*3600))
+	for _, c := range []struct {
+		d    Dialect
+		v    interface{}
+		want string
+	}{
+		{Postgres, nil, "NULL"},
+		{Postgres, int64(-3), "-3"},
+		{Postgres, 0.5, "0.5"},
+		{Postgres, true, "TRUE"},
+		{SQLite, true, "1"},
+		{SQLite, false, "0"},
+		{Postgres, "O'Brien\\", `'O''Brien\'`},
+		{SQLite, "O'Brien\\", `'O''Brien\'`},
+		{MySQL, "O'Brien\\\x00\r\x1a", `'O\'Brien\\\0\r\Z'`},
+		{Postgres, []byte{0xde, 0xad}, `'\xdead'`},
+		{SQLite, []byte{0xde, 0xad}, `X'dead'`},
+		{Postgres, at, "'2020-01-02 03:04:05.6-08:00'"},
+		{MySQL, at, "'2020-01-02 11:04:05.6'"},
+	} {
+		if got := c.d.literal(c.v); got != c.want {
+			t.Errorf("%d %#v: expected %s, got %s", c.d, c.v, c.want, got)
+		}
+	}
+
+	if got := MySQL.quoteTable("shop.or`der"); got != "`shop`.`or``der`" {
+		t.Errorf("expected a quoted MySQL table, got %s", got)
+	}
+	if got := Postgres.quoteIdent(`a"b`); got != `"a""b"` {
+		t.Errorf("expected a quoted Postgres identifier, got %s", got)
+	}
+}
+
+func TestWriteInsertsFilled(t *testing.T) {
+	type post struct {
+		ID    string `db:"id" lorem:"uuid"`
+		Title string `db:"title" lorem:"sentence,3,8"`
+		Body  string `db:"body" lorem:"paragraph,1,3"`
+		Views int    `db:"views"`
+	}
+	var a, b bytes.Buffer
+	if err := WriteInserts(&a, "posts", post{}, 250, SQLConfig{}, WithSeed(1)); err != nil {
+		t.Fatal(err.Error())
+	}
+	if err := WriteInserts(&b, "posts", post{}, 250, SQLConfig{}, WithSeed(1)); err != nil {
+		t.Fatal(err.Error())
+	}
+	if a.String() != b.String() {
+		t.Error("expected the same statements for the same seed")
+	}
+	if n := strings.Count(a.String(), "INSERT INTO"); n != 3 {
+		t.Errorf("expected 3 statements of up to 100 rows, got %d", n)
+	}
+	if n := strings.Count(a.String(), ");\n"); n != 3 {
+		t.Errorf("expected 3 terminated statements, got %d", n)
+	}
+}
@@ -0,0 +1,148 @@
+package lorem
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// KindFunc generates a value for a custom tag kind. args are the
+// comma separated arguments following the kind in the tag, so
+// `lorem:"sku,8"` calls the "sku" KindFunc with []string{"8"}.
+// The returned value is assigned to the field, converting it where
+// possible (a string can fill an int field, an int can fill a string
+// field and so on).
+type KindFunc func(g *Generator, args []string) (interface{}, error)
+
+// builtinKinds are the kinds handled by stringFromTag, and the other
+// functions of template tags, which can't be replaced with RegisterKind
+var builtinKinds = map[string]bool{
+	"word":         true,
+	"sentence":     true,
+	"paragraph":    true,
+	"url":          true,
+	"readablepath": true,
+	"host":         true,
+	"email":        true,
+	"uuid":         true,
+	"firstname":    true,
+	"lastname":     true,
+	"name":         true,
+	"phone":        true,
+	"time":         true,
+	"oneof":        true,
+	"template":     true,
+	// template functions
+	"intRange": true,
+	"digits":   true,
+	"letters":  true,
+	"year":     true,
+	"upper":    true,
+	"lower":    true,
+	"kind":     true,
+}
+
+var (
+	kindsMu sync.RWMutex
+	kinds   = map[string]KindFunc{}
+)
+
+// RegisterKind makes a new tag kind available to every struct,
+// so `lorem:"name,args..."` fills the field with the result of fn.
+// RegisterKind panics if name is empty, contains a comma, is a built in
+// kind or template function or has already been registered.
+func RegisterKind(name string, fn KindFunc) {
+	if name == "" || name == "-" || strings.ContainsAny(name, ",[") {
+		panic(fmt.Sprintf("lorem: RegisterKind invalid kind name %q", name))
+	}
+	if fn == nil {
+		panic("lorem: RegisterKind fn is nil for kind " + name)
+	}
+	if builtinKinds[name] {
+		panic("lorem: RegisterKind kind " + name + " is built in")
+	}
+
+	kindsMu.Lock()
+	defer kindsMu.Unlock()
+	if _, dup := kinds[name]; dup {
+		panic("lorem: RegisterKind called twice for kind " + name)
+	}
+	kinds[name] = fn
+}
+
+// customKind returns the registered KindFunc for the tag's kind
+// and the tag's arguments
+func customKind(tag string) (KindFunc, []string, bool) {
+	args := strings.Split(tag, ",")
+	kindsMu.RLock()
+	defer kindsMu.RUnlock()
+	fn, ok := kinds[args[0]]
+	return fn, args[1:], ok
+}
+
+// setValue assigns v to field, converting between
+// strings and other simple types where needed
+func setValue(field reflect.Value, v interface{}) error {
+	if v == nil {
+		return nil
+	}
+	rv := reflect.ValueOf(v)
+	typ := field.Type()
+
+	if typ.Kind() == reflect.Ptr && rv.Type() != typ {
+		if field.IsNil() {
+			field.Set(reflect.New(typ.Elem()))
+		}
+		return setValue(field.Elem(), v)
+	}
+
+	switch {
+	case rv.Type().AssignableTo(typ):
+		field.Set(rv)
+		return nil
+	case typ.Kind() == reflect.String:
+		field.SetString(fmt.Sprint(v))
+		return nil
+	case rv.Kind() == reflect.String:
+		return setString(field, rv.String())
+	case rv.Type().ConvertibleTo(typ):
+		field.Set(rv.Convert(typ))
+		return nil
+	}
+	return fmt.Errorf("cannot assign %T to %s", v, typ)
+}
+
+// setString parses str into the simple type of field
+func setString(field reflect.Value, str string) error {
+	switch field.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(str, 10, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(str, 10, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(str, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetFloat(f)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(str)
+		if err != nil {
+			return err
+		}
+		field.SetBool(b)
+	default:
+		return fmt.Errorf("cannot assign string to %s", field.Type())
+	}
+	return nil
+}
@@ -0,0 +1,124 @@
+package lorem
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func init() {
+	RegisterKind("sku", func(g *Generator, args []string) (interface{}, error) {
+		n := 6
+		if len(args) > 0 {
+			var err error
+			if n, err = strconv.Atoi(args[0]); err != nil {
+				return nil, err
+			}
+		}
+		return "SKU-" + strings.Repeat("7", n), nil
+	})
+	RegisterKind("answer", func(g *Generator, args []string) (interface{}, error) {
+		return 42, nil
+	})
+	RegisterKind("ticker", func(g *Generator, args []string) (interface{}, error) {
+		return Ticker{Symbol: strings.ToUpper(Word(3, 5))}, nil
+	})
+	RegisterKind("tenant-id", func(g *Generator, args []string) (interface{}, error) {
+		return "tenant-" + strings.Join(args, "-"), nil
+	})
+	RegisterKind("broken", func(g *Generator, args []string) (interface{}, error) {
+		return nil, errors.New("broken kind")
+	})
+}
+
+type Ticker struct {
+	Symbol string
+}
+
+type StructWithCustomKinds struct {
+	SKU           string            `lorem:"sku"`
+	SKUWithArgs   string            `lorem:"sku,2"`
+	SKUPointer    *string           `lorem:"sku"`
+	SKUs          []string          `lorem:"[2,2]sku,3"`
+	Answer        int               `lorem:"answer"`
+	AnswerString  string            `lorem:"answer"`
+	AnswerFloat   *float64          `lorem:"answer"`
+	Ticker        Ticker            `lorem:"ticker"`
+	Decoded       SubStructLikeWord `lorem:"sku,1"`
+	UnknownIsWord string            `lorem:"nosuchkind"`
+}
+
+func TestCustomKinds(t *testing.T) {
+	var ss StructWithCustomKinds
+	if err := Fill(&ss); err != nil {
+		t.Error(err.Error())
+	}
+
+	if ss.SKU != "SKU-777777" {
+		t.Errorf("SKU: expected %s, got %s", "SKU-777777", ss.SKU)
+	}
+	if ss.SKUWithArgs != "SKU-77" {
+		t.Errorf("SKUWithArgs: expected %s, got %s", "SKU-77", ss.SKUWithArgs)
+	}
+	if ss.SKUPointer == nil || *ss.SKUPointer != "SKU-777777" {
+		t.Errorf("SKUPointer: expected %s, got %v", "SKU-777777", ss.SKUPointer)
+	}
+	if len(ss.SKUs) != 2 {
+		t.Errorf("SKUs: expected %d entries, got %d", 2, len(ss.SKUs))
+	}
+	for _, s := range ss.SKUs {
+		if s != "SKU-777" {
+			t.Errorf("SKUs: expected %s, got %s", "SKU-777", s)
+		}
+	}
+	if ss.Answer != 42 {
+		t.Errorf("Answer: expected %d, got %d", 42, ss.Answer)
+	}
+	if ss.AnswerString != "42" {
+		t.Errorf("AnswerString: expected %s, got %s", "42", ss.AnswerString)
+	}
+	if ss.AnswerFloat == nil || *ss.AnswerFloat != 42 {
+		t.Errorf("AnswerFloat: expected %d, got %v", 42, ss.AnswerFloat)
+	}
+	if len(ss.Ticker.Symbol) < 3 || strings.ToUpper(ss.Ticker.Symbol) != ss.Ticker.Symbol {
+		t.Errorf("Ticker: expected upper case symbol, got %s", ss.Ticker.Symbol)
+	}
+	if ss.Decoded.word != "SKU-7" {
+		t.Errorf("Decoded: expected %s, got %s", "SKU-7", ss.Decoded.word)
+	}
+	if ss.UnknownIsWord != "" {
+		t.Errorf("UnknownIsWord: expected empty string, got %s", ss.UnknownIsWord)
+	}
+}
+
+func TestCustomKindErrors(t *testing.T) {
+	var broken struct {
+		Broken string `lorem:"broken"`
+	}
+	if err := Fill(&broken); err == nil {
+		t.Error("Expected error, got nil")
+	}
+
+	var mismatched struct {
+		Ticker int `lorem:"ticker"`
+	}
+	if err := Fill(&mismatched); err == nil {
+		t.Error("Expected error, got nil")
+	}
+}
+
+func TestRegisterKindConflicts(t *testing.T) {
+	for _, name := range []string{"word", "email", "sku", "digits", "intRange", "upper", "", "a,b", "-"} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Expected RegisterKind(%q) to panic", name)
+				}
+			}()
+			RegisterKind(name, func(g *Generator, args []string) (interface{}, error) {
+				return nil, nil
+			})
+		}()
+	}
+}
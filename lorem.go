@@ -5,13 +5,18 @@
 package lorem
 
 import (
+	"fmt"
 	"math/rand"
+	"strconv"
 	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
 )
 
 // Generate a natural word len.
-func genWordLen() int {
-	f := rand.Float32() * 100
+func (g *Generator) genWordLen() int {
+	f := g.rand.Float32() * 100
 	// a table of word lengths and their frequencies.
 	switch {
 	case f < 1.939:
@@ -46,17 +51,26 @@ func genWordLen() int {
 
 // IntRange returns a random int between min (inclusive) and max (exclusive)
 func IntRange(min, max int) int {
+	return std.IntRange(min, max)
+}
+
+// IntRange returns a random int between min (inclusive) and max (exclusive)
+func (g *Generator) IntRange(min, max int) int {
 	if min == max {
-		return IntRange(min, min+1)
+		return g.IntRange(min, min+1)
 	}
 	if min > max {
-		return IntRange(max, min)
+		return g.IntRange(max, min)
 	}
-	n := rand.Int() % (max - min)
+	n := g.rand.Int() % (max - min)
 	return n + min
 }
 
 func word(wordLen int) string {
+	return std.word(wordLen)
+}
+
+func (g *Generator) word(wordLen int) string {
 	if wordLen < 1 {
 		wordLen = 1
 	}
@@ -64,22 +78,51 @@ func word(wordLen int) string {
 		wordLen = 13
 	}
 
-	n := rand.Int() % len(wordlist)
-	for {
-		if n >= len(wordlist)-1 {
-			n = 0
+	byLen := latinWords
+	if g.corpus != nil {
+		byLen = g.corpus
+	}
+	// a corpus may not have a word of every length,
+	// so settle for the closest lengths that it has
+	for d := 0; ; d++ {
+		shorter, longer := byLen[wordLen-d], byLen[wordLen+d]
+		if d == 0 {
+			longer = nil
+		}
+		if n := len(shorter) + len(longer); n > 0 {
+			i := g.rand.Int() % n
+			if i < len(shorter) {
+				return shorter[i]
+			}
+			return longer[i-len(shorter)]
 		}
-		if len(wordlist[n]) == wordLen {
-			return wordlist[n]
+	}
+}
+
+// latinWords is the built in word list by length
+var latinWords = wordsByLen(wordlist)
+
+// wordsByLen indexes words by their length, so each
+// word of a given length is as likely to be picked
+func wordsByLen(words []string) map[int][]string {
+	byLen := map[int][]string{}
+	for _, w := range words {
+		if w != "" {
+			byLen[len(w)] = append(byLen[len(w)], w)
 		}
-		n++
 	}
+	return byLen
 }
 
 // Word Generates a word in a specfied range of letters.
 func Word(min, max int) string {
-	n := IntRange(min, max)
-	return word(n)
+	return std.Word(min, max)
+}
+
+// Word Generates a word in a specfied range of letters.
+func (g *Generator) Word(min, max int) string {
+	n := g.IntRange(min, max)
+	return g.word(n)
 }
 
 const letterBytes = "abcdefghijklmnopqrstuvwxyz0123456789"
@@ -114,18 +157,23 @@ func HerokuDBName(src rand.Source) string {
 
 // Sentence Generate a sentence with a specified range of words.
 func Sentence(min, max int) string {
-	n := IntRange(min, max)
+	return std.Sentence(min, max)
+}
+
+// Sentence Generate a sentence with a specified range of words.
+func (g *Generator) Sentence(min, max int) string {
+	n := g.IntRange(min, max)
 
 	// grab some words
 	ws := []string{}
 	maxcommas := 2
 	numcomma := 0
 	for i := 0; i < n; i++ {
-		ws = append(ws, (word(genWordLen())))
+		ws = append(ws, (g.word(g.genWordLen())))
 
 		// maybe insert a comma, if there are currently < 2 commas, and
 		// the current word is not the last or first
-		if (rand.Int()%n == 0) && numcomma < maxcommas && i < n-1 && i > 2 {
+		if (g.rand.Int()%n == 0) && numcomma < maxcommas && i < n-1 && i > 2 {
 			ws[i-1] += ","
 			numcomma++
 		}
@@ -133,8 +181,8 @@ func Sentence(min, max int) string {
 	}
 
 	sentence := strings.Join(ws, " ") + "."
-	sentence = strings.ToUpper(sentence[:1]) + sentence[1:]
-	return sentence
+	first, size := utf8.DecodeRuneInString(sentence)
+	return string(unicode.ToUpper(first)) + sentence[size:]
 }
 
 const (
@@ -144,28 +192,38 @@ const (
 
 // Paragraph Generates a paragraph with a specified range of sentenences.
 func Paragraph(min, max int) string {
-	n := IntRange(min, max)
+	return std.Paragraph(min, max)
+}
+
+// Paragraph Generates a paragraph with a specified range of sentenences.
+func (g *Generator) Paragraph(min, max int) string {
+	n := g.IntRange(min, max)
 
 	p := []string{}
 	for i := 0; i < n; i++ {
-		p = append(p, Sentence(minwords, maxwords))
+		p = append(p, g.Sentence(minwords, maxwords))
 	}
 	return strings.Join(p, " ")
 }
 
 // URL Generates a random URL
 func URL() string {
-	n := IntRange(0, 3)
+	return std.URL()
+}
+
+// URL Generates a random URL
+func (g *Generator) URL() string {
+	n := g.IntRange(0, 3)
 
-	base := `http://www.` + Host()
+	base := `http://www.` + g.Host()
 
 	switch n {
 	case 0:
 		break
 	case 1:
-		base += "/" + Word(2, 8)
+		base += "/" + g.Word(2, 8)
 	case 2:
-		base += "/" + Word(2, 8) + "/" + Word(2, 8) + ".html"
+		base += "/" + g.Word(2, 8) + "/" + g.Word(2, 8) + ".html"
 	}
 	return base
 }
@@ -181,7 +239,12 @@ func ReadablePath(sentence string) string {
 
 // Host generates a random host string (dfdfd.com) for example
 func Host() string {
-	n := IntRange(0, 3)
+	return std.Host()
+}
+
+// Host generates a random host string (dfdfd.com) for example
+func (g *Generator) Host() string {
+	n := g.IntRange(0, 3)
 	tld := ""
 	switch n {
 	case 0:
@@ -192,11 +255,134 @@ func Host() string {
 		tld = ".org"
 	}
 
-	parts := []string{Word(2, 8), Word(2, 8), tld}
+	parts := []string{g.Word(2, 8), g.Word(2, 8), tld}
 	return strings.Join(parts, ``)
 }
 
 // Email generates a random email (dfdf@Host())
 func Email() string {
-	return Word(4, 10) + `@` + Host()
+	return std.Email()
+}
+
+// Email generates a random email (dfdf@Host())
+func (g *Generator) Email() string {
+	return g.Word(4, 10) + `@` + g.Host()
+}
+
+// FirstName generates a random first name
+func FirstName() string {
+	return std.FirstName()
+}
+
+// FirstName generates a random first name
+func (g *Generator) FirstName() string {
+	return firstNameList[g.rand.Int()%len(firstNameList)]
+}
+
+// LastName generates a random last name
+func LastName() string {
+	return std.LastName()
+}
+
+// LastName generates a random last name
+func (g *Generator) LastName() string {
+	return lastNameList[g.rand.Int()%len(lastNameList)]
+}
+
+// Name generates a random full name (FirstName() LastName())
+func Name() string {
+	return std.Name()
+}
+
+// Name generates a random full name (FirstName() LastName())
+func (g *Generator) Name() string {
+	return g.FirstName() + " " + g.LastName()
+}
+
+// Phone generates a random north american style phone number ((555) 555-5555)
+func Phone() string {
+	return std.Phone()
+}
+
+// Phone generates a random north american style phone number ((555) 555-5555)
+func (g *Generator) Phone() string {
+	return fmt.Sprintf("(%03d) %03d-%04d", g.IntRange(200, 1000), g.IntRange(200, 1000), g.IntRange(0, 10000))
+}
+
+// Time generates a random time within the last year, truncated to the second
+func Time() time.Time {
+	return std.Time()
+}
+
+// Time generates a random time within the year before the generator's
+// current time (see WithNow), truncated to the second
+func (g *Generator) Time() time.Time {
+	year := int64(365 * 24 * time.Hour / time.Second)
+	return g.now().Add(-time.Duration(g.rand.Int63n(year)) * time.Second).Truncate(time.Second)
+}
+
+// UUID generates a random (version 4) UUID string
+func UUID() string {
+	return std.UUID()
+}
+
+// UUID generates a random (version 4) UUID string
+func (g *Generator) UUID() string {
+	var b [16]byte
+	for i := range b {
+		b[i] = byte(g.rand.Int())
+	}
+	b[6] = b[6]&0x0f | 0x40 // version 4
+	b[8] = b[8]&0x3f | 0x80 // RFC 4122 variant
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
+
+// Text generates n characters of words separated by spaces,
+// which never starts or ends with a space
+func Text(n int) string {
+	return std.Text(n)
+}
+
+// Text generates n characters of words separated by spaces,
+// which never starts or ends with a space, and "" if n isn't positive
+func (g *Generator) Text(n int) string {
+	if n <= 0 {
+		return ""
+	}
+	var b strings.Builder
+	for b.Len() < n {
+		if b.Len() > 0 {
+			b.WriteByte(' ')
+		}
+		b.WriteString(g.Word(2, 10))
+	}
+	str := []byte(b.String()[:n])
+	if n > 0 && str[n-1] == ' ' {
+		str[n-1] = byte('a' + g.IntRange(0, 26))
+	}
+	return string(str)
+}
+
+// IPv4 generates a random IPv4 address (10.0.12.7)
+func IPv4() string {
+	return std.IPv4()
+}
+
+// IPv4 generates a random IPv4 address (10.0.12.7)
+func (g *Generator) IPv4() string {
+	return fmt.Sprintf("%d.%d.%d.%d", g.IntRange(1, 256), g.IntRange(0, 256), g.IntRange(0, 256), g.IntRange(1, 255))
+}
+
+// IPv6 generates a random IPv6 address of eight groups (2001:db8:0:0:0:ff00:42:8329)
+func IPv6() string {
+	return std.IPv6()
+}
+
+// IPv6 generates a random IPv6 address of eight groups (2001:db8:0:0:0:ff00:42:8329)
+func (g *Generator) IPv6() string {
+	groups := make([]string, 8)
+	for i := range groups {
+		groups[i] = strconv.FormatInt(int64(g.IntRange(0, 1<<16)), 16)
+	}
+	return strings.Join(groups, ":")
 }
@@ -6,6 +6,8 @@ package lorem
 
 import (
 	"log"
+	"net"
+	"strings"
 	"testing"
 )
 
@@ -20,5 +22,27 @@ func TestLorem(t *testing.T) {
 		log.Print(URL())
 		log.Print(Host())
 		log.Print(Email())
+		log.Print(Name())
+		log.Print(Phone())
+		log.Print(Time())
+	}
+}
+
+func TestTextAndAddresses(t *testing.T) {
+	g := NewGenerator(WithSeed(1))
+	for n := 0; n < 40; n++ {
+		text := g.Text(n)
+		if len(text) != n || strings.HasPrefix(text, " ") || strings.HasSuffix(text, " ") {
+			t.Errorf("Text: expected %d characters not starting or ending with a space, got %q", n, text)
+		}
+		if ip := net.ParseIP(g.IPv4()); ip == nil || ip.To4() == nil {
+			t.Errorf("IPv4: expected an IPv4 address, got %v", ip)
+		}
+		if ip := g.IPv6(); net.ParseIP(ip) == nil || strings.Count(ip, ":") != 7 {
+			t.Errorf("IPv6: expected an IPv6 address, got %q", ip)
+		}
+	}
+	if text := g.Text(-3); text != "" {
+		t.Errorf("Text: expected nothing for a negative length, got %q", text)
 	}
 }
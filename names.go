@@ -0,0 +1,99 @@
+package lorem
+
+import "strings"
+
+var firstNameList = strings.Split(firstNames, "\n")
+
+var lastNameList = strings.Split(lastNames, "\n")
+
+var firstNames = `Aaron
+Abigail
+Adam
+Alice
+Amelia
+Andrew
+Anna
+Benjamin
+Charlotte
+Chloe
+Daniel
+David
+Eleanor
+Elijah
+Emily
+Emma
+Ethan
+Grace
+Hannah
+Henry
+Isaac
+Isabella
+Jack
+James
+Julia
+Leo
+Liam
+Lucas
+Lucy
+Maria
+Mason
+Mia
+Noah
+Oliver
+Olivia
+Owen
+Samuel
+Sarah
+Sofia
+Sophie
+Thomas
+Victoria
+William
+Zoe`
+
+var lastNames = `Adams
+Allen
+Anderson
+Baker
+Brown
+Campbell
+Carter
+Clark
+Davis
+Evans
+Garcia
+Green
+Hall
+Harris
+Hernandez
+Hill
+Jackson
+Johnson
+Jones
+King
+Lee
+Lewis
+Lopez
+Martin
+Martinez
+Miller
+Mitchell
+Moore
+Nelson
+Parker
+Perez
+Roberts
+Robinson
+Rodriguez
+Scott
+Smith
+Taylor
+Thomas
+Thompson
+Turner
+Walker
+White
+Williams
+Wilson
+Wright
+Young`
@@ -0,0 +1,187 @@
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"mime"
+	"sort"
+	"strings"
+
+	lorem "github.com/axiomzen/golorem"
+	"github.com/axiomzen/golorem/schema"
+)
+
+// Body is a generated request or response body
+type Body struct {
+	Method      string
+	Path        string
+	OperationID string
+	// Status is the response's status code (or "default"),
+	// and empty for the request body
+	Status    string
+	MediaType string
+	Value     interface{}
+}
+
+// Bytes encodes the body for its media type: text types are written as
+// they are when the value is a string, and everything else as JSON
+func (b Body) Bytes() ([]byte, error) {
+	mediaType, _, _ := mime.ParseMediaType(b.MediaType)
+	if str, ok := b.Value.(string); ok && strings.HasPrefix(mediaType, "text/") {
+		return []byte(str), nil
+	}
+	return json.Marshal(b.Value)
+}
+
+// Bodies generates a body for every request and response media type of
+// every operation in the document, ordered by path, method, request
+// before responses, status and media type
+func (d *Document) Bodies(opts ...lorem.Option) ([]Body, error) {
+	g := lorem.NewGenerator(opts...)
+	sg := schema.NewGenerator(g, d.Resolve)
+
+	paths := make([]string, 0, len(d.Paths))
+	for path := range d.Paths {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	var bodies []Body
+	for _, path := range paths {
+		ops := d.Paths[path].Operations()
+		for _, method := range sortedKeys(ops) {
+			op := ops[method]
+			next := func(status string, content map[string]*MediaType) error {
+				for _, mediaType := range sortedKeys(content) {
+					v, err := d.generate(g, sg, content[mediaType])
+					if err != nil {
+						return fmt.Errorf("%s %s %s %s: %w", method, path, status, mediaType, err)
+					}
+					bodies = append(bodies, Body{
+						Method:      method,
+						Path:        path,
+						OperationID: op.OperationID,
+						Status:      status,
+						MediaType:   mediaType,
+						Value:       v,
+					})
+				}
+				return nil
+			}
+
+			if op.RequestBody != nil {
+				req, err := d.requestBody(op.RequestBody)
+				if err != nil {
+					return nil, err
+				}
+				if err := next("", req.Content); err != nil {
+					return nil, err
+				}
+			}
+			for _, status := range sortedKeys(op.Responses) {
+				resp, err := d.response(op.Responses[status])
+				if err != nil {
+					return nil, err
+				}
+				if err := next(status, resp.Content); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+	return bodies, nil
+}
+
+// Request generates a request body for the operation at method and
+// path, in the given media type or the first one if it's empty
+func (d *Document) Request(method, path, mediaType string, opts ...lorem.Option) (Body, error) {
+	return d.body(method, path, "", mediaType, opts)
+}
+
+// Response generates a body for the operation's response with the
+// given status, in the given media type or the first one if it's empty
+func (d *Document) Response(method, path, status, mediaType string, opts ...lorem.Option) (Body, error) {
+	return d.body(method, path, status, mediaType, opts)
+}
+
+func (d *Document) body(method, path, status, mediaType string, opts []lorem.Option) (Body, error) {
+	method = strings.ToUpper(method)
+	body := Body{Method: method, Path: path, Status: status, MediaType: mediaType}
+	item, ok := d.Paths[path]
+	if !ok {
+		return body, fmt.Errorf("no path %s", path)
+	}
+	op, ok := item.Operations()[method]
+	if !ok {
+		return body, fmt.Errorf("no operation %s %s", method, path)
+	}
+	body.OperationID = op.OperationID
+
+	var content map[string]*MediaType
+	if status == "" {
+		if op.RequestBody == nil {
+			return body, fmt.Errorf("%s %s has no request body", method, path)
+		}
+		req, err := d.requestBody(op.RequestBody)
+		if err != nil {
+			return body, err
+		}
+		content = req.Content
+	} else {
+		resp, ok := op.Responses[status]
+		if !ok {
+			return body, fmt.Errorf("%s %s has no %s response", method, path, status)
+		}
+		resp, err := d.response(resp)
+		if err != nil {
+			return body, err
+		}
+		content = resp.Content
+	}
+
+	if body.MediaType == "" {
+		if keys := sortedKeys(content); len(keys) > 0 {
+			body.MediaType = keys[0]
+		}
+	}
+	mt, ok := content[body.MediaType]
+	if !ok {
+		return body, fmt.Errorf("%s %s has no %s body", method, path, body.MediaType)
+	}
+	g := lorem.NewGenerator(opts...)
+	var err error
+	body.Value, err = d.generate(g, schema.NewGenerator(g, d.Resolve), mt)
+	return body, err
+}
+
+// generate returns the media type's example if it has one, one of its
+// named examples if it has those, and otherwise a value for its schema
+func (d *Document) generate(g *lorem.Generator, sg *schema.Generator, mt *MediaType) (interface{}, error) {
+	if mt == nil {
+		return nil, nil
+	}
+	if mt.Example != nil {
+		return mt.Example, nil
+	}
+	if len(mt.Examples) > 0 {
+		names := sortedKeys(mt.Examples)
+		example, err := d.example(mt.Examples[names[g.IntRange(0, len(names))]])
+		if err != nil {
+			return nil, err
+		}
+		return example.Value, nil
+	}
+	if mt.Schema == nil {
+		return nil, nil
+	}
+	return sg.Generate(mt.Schema)
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,154 @@
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/mail"
+	"reflect"
+	"testing"
+
+	lorem "github.com/axiomzen/golorem"
+)
+
+func checkPet(t *testing.T, name string, v interface{}, withID bool) {
+	pet, ok := v.(map[string]interface{})
+	if !ok {
+		t.Errorf("%s: expected an object, got %v", name, v)
+		return
+	}
+	if n, _ := pet["name"].(string); len(n) < 2 || len(n) > 20 {
+		t.Errorf("%s: expected a name of 2 to 20 characters, got %v", name, pet["name"])
+	}
+	if contact, _ := pet["contact"].(string); contact == "" {
+		t.Errorf("%s: expected a contact, got %v", name, pet["contact"])
+	} else if _, err := mail.ParseAddress(contact); err != nil {
+		t.Errorf("%s: expected an email contact, got %q", name, contact)
+	}
+	if weight, ok := pet["weight"].(float64); ok && (weight <= 0 || weight > 80) {
+		t.Errorf("%s: expected a weight above 0 up to 80, got %v", name, weight)
+	}
+	if id, _ := pet["id"].(float64); withID && id < 1 {
+		t.Errorf("%s: expected an id of at least 1, got %v", name, pet["id"])
+	}
+}
+
+func TestBodies(t *testing.T) {
+	doc, err := LoadFile("testdata/petstore.json")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	for seed := int64(0); seed < 20; seed++ {
+		bodies, err := doc.Bodies(lorem.WithSeed(seed))
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+
+		var got []string
+		for _, body := range bodies {
+			got = append(got, fmt.Sprintf("%s %s %s %s", body.Method, body.Path, body.Status, body.MediaType))
+			name := got[len(got)-1]
+			switch body.OperationID + " " + body.Status {
+			case "listPets 200":
+				pets := body.Value.([]interface{})
+				if len(pets) < 1 || len(pets) > 3 {
+					t.Errorf("%s: expected 1 to 3 pets, got %v", name, pets)
+				}
+				for _, pet := range pets {
+					checkPet(t, name, pet, true)
+				}
+			case "listPets default":
+				e := body.Value.(map[string]interface{})
+				if code := e["code"].(float64); code < 400 || code > 599 || e["message"] != "something went wrong" {
+					t.Errorf("%s: expected an error, got %v", name, e)
+				}
+			case "createPet ":
+				checkPet(t, name, body.Value, false)
+			case "createPet 201":
+				if body.MediaType == "application/json" {
+					checkPet(t, name, body.Value, true)
+				} else if b, _ := body.Bytes(); len(b) != 36 {
+					t.Errorf("%s: expected a plain uuid, got %s", name, b)
+				}
+			case "showPet 200":
+				if n := body.Value.(map[string]interface{})["name"]; n != "Rex" && n != "Tom" {
+					t.Errorf("%s: expected one of the named examples, got %v", name, body.Value)
+				}
+			case "showPet 404":
+				if b, _ := body.Bytes(); string(b) != `{"code":404,"message":"not found"}` {
+					t.Errorf("%s: expected the example, got %s", name, b)
+				}
+			}
+		}
+
+		want := []string{
+			"GET /pets 200 application/json",
+			"GET /pets default application/json",
+			"POST /pets  application/json",
+			"POST /pets 201 application/json",
+			"POST /pets 201 text/plain",
+			"GET /pets/{id} 200 application/json",
+			"GET /pets/{id} 404 application/json",
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Fatalf("expected bodies %q, got %q", want, got)
+		}
+	}
+}
+
+func TestBodiesSeeds(t *testing.T) {
+	doc, err := LoadFile("testdata/petstore.json")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	a, err := doc.Bodies(lorem.WithSeed(3))
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	b, err := doc.Bodies(lorem.WithSeed(3))
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	ja, _ := json.Marshal(a)
+	jb, _ := json.Marshal(b)
+	if string(ja) != string(jb) {
+		t.Errorf("expected the same bodies for the same seed, got %s and %s", ja, jb)
+	}
+}
+
+func TestRequestResponse(t *testing.T) {
+	doc, err := LoadFile("testdata/petstore.json")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	req, err := doc.Request("post", "/pets", "")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if req.MediaType != "application/json" || req.OperationID != "createPet" {
+		t.Errorf("expected the createPet JSON request, got %+v", req)
+	}
+	checkPet(t, "request", req.Value, false)
+
+	resp, err := doc.Response("POST", "/pets", "201", "text/plain")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if _, ok := resp.Value.(string); !ok {
+		t.Errorf("expected a text response, got %v", resp.Value)
+	}
+
+	errs := [][4]string{
+		{"GET", "/missing", "200", ""},
+		{"PUT", "/pets", "200", ""},
+		{"GET", "/pets", "", ""},
+		{"GET", "/pets", "500", ""},
+		{"GET", "/pets", "200", "application/xml"},
+	}
+	for _, e := range errs {
+		if _, err := doc.Response(e[0], e[1], e[2], e[3]); err == nil {
+			t.Errorf("%v: expected an error", e)
+		}
+	}
+}
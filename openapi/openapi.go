@@ -0,0 +1,203 @@
+// Package openapi generates example request and response bodies for the
+// operations of an OpenAPI 3 document, using the schema package for the
+// schemas and any examples the document gives. Documents are read as JSON;
+// YAML documents have to be converted to JSON first.
+//
+//	doc, err := openapi.LoadFile("petstore.json")
+//	bodies, err := doc.Bodies(lorem.WithSeed(42))
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/axiomzen/golorem/schema"
+)
+
+// Document is an OpenAPI 3 document. Only what's needed
+// to generate bodies is kept.
+type Document struct {
+	OpenAPI    string               `json:"openapi"`
+	Paths      map[string]*PathItem `json:"paths"`
+	Components Components           `json:"components"`
+}
+
+// Components holds the reusable parts of a document
+// that references can point to
+type Components struct {
+	Schemas       map[string]*schema.Schema `json:"schemas"`
+	Responses     map[string]*Response      `json:"responses"`
+	RequestBodies map[string]*RequestBody   `json:"requestBodies"`
+	Examples      map[string]*Example       `json:"examples"`
+}
+
+// PathItem holds the operations on a path
+type PathItem struct {
+	Get     *Operation `json:"get"`
+	Put     *Operation `json:"put"`
+	Post    *Operation `json:"post"`
+	Delete  *Operation `json:"delete"`
+	Options *Operation `json:"options"`
+	Head    *Operation `json:"head"`
+	Patch   *Operation `json:"patch"`
+	Trace   *Operation `json:"trace"`
+}
+
+// Operations returns the path's operations by method
+// (in upper case, as in net/http)
+func (p *PathItem) Operations() map[string]*Operation {
+	ops := map[string]*Operation{}
+	for method, op := range map[string]*Operation{
+		"GET": p.Get, "PUT": p.Put, "POST": p.Post, "DELETE": p.Delete,
+		"OPTIONS": p.Options, "HEAD": p.Head, "PATCH": p.Patch, "TRACE": p.Trace,
+	} {
+		if op != nil {
+			ops[method] = op
+		}
+	}
+	return ops
+}
+
+// Operation is a single API operation
+type Operation struct {
+	OperationID string               `json:"operationId"`
+	RequestBody *RequestBody         `json:"requestBody"`
+	Responses   map[string]*Response `json:"responses"`
+}
+
+// RequestBody describes an operation's request body
+type RequestBody struct {
+	Ref     string                `json:"$ref"`
+	Content map[string]*MediaType `json:"content"`
+}
+
+// Response describes one of an operation's responses
+type Response struct {
+	Ref     string                `json:"$ref"`
+	Content map[string]*MediaType `json:"content"`
+}
+
+// MediaType is a body's schema and examples for one media type
+type MediaType struct {
+	Schema   *schema.Schema      `json:"schema"`
+	Example  interface{}         `json:"example"`
+	Examples map[string]*Example `json:"examples"`
+}
+
+// Example is a named example value
+type Example struct {
+	Ref   string      `json:"$ref"`
+	Value interface{} `json:"value"`
+}
+
+// errYAML is returned by Load for a document that isn't a JSON object,
+// which is most likely written in YAML
+var errYAML = errors.New("YAML documents are not supported, convert to JSON")
+
+// Load reads an OpenAPI 3 document written in JSON
+func Load(r io.Reader) (*Document, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] != '{' {
+		return nil, errYAML
+	}
+	doc := &Document{}
+	if err := json.Unmarshal(data, doc); err != nil {
+		return nil, err
+	}
+	if !strings.HasPrefix(doc.OpenAPI, "3.") {
+		return nil, fmt.Errorf("unsupported OpenAPI version %q", doc.OpenAPI)
+	}
+	return doc, nil
+}
+
+// LoadFile reads an OpenAPI 3 document written in JSON from a file
+func LoadFile(name string) (*Document, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return Load(f)
+}
+
+// component splits a reference such as "#/components/schemas/Pet/items"
+// into the component's name ("Pet") and what follows ("/items"), checking
+// it is of the given kind
+func component(ref, kind string) (string, string, error) {
+	rest, ok := strings.CutPrefix(ref, "#/components/"+kind+"/")
+	if !ok {
+		return "", "", fmt.Errorf("unsupported reference %s", ref)
+	}
+	name, pointer, _ := strings.Cut(rest, "/")
+	if pointer != "" {
+		pointer = "/" + pointer
+	}
+	return name, pointer, nil
+}
+
+// Resolve returns the schema a reference such as "#/components/schemas/Pet"
+// points to, for use as a schema.Resolver
+func (d *Document) Resolve(ref string) (*schema.Schema, error) {
+	name, pointer, err := component(ref, "schemas")
+	if err != nil {
+		return nil, err
+	}
+	s, ok := d.Components.Schemas[name]
+	if !ok {
+		return nil, fmt.Errorf("reference %s not found", ref)
+	}
+	return s.Resolve("#" + pointer)
+}
+
+// response follows r's reference, if it has one
+func (d *Document) response(r *Response) (*Response, error) {
+	if r.Ref == "" {
+		return r, nil
+	}
+	name, _, err := component(r.Ref, "responses")
+	if err != nil {
+		return nil, err
+	}
+	if resolved, ok := d.Components.Responses[name]; ok {
+		return resolved, nil
+	}
+	return nil, fmt.Errorf("reference %s not found", r.Ref)
+}
+
+// requestBody follows r's reference, if it has one
+func (d *Document) requestBody(r *RequestBody) (*RequestBody, error) {
+	if r.Ref == "" {
+		return r, nil
+	}
+	name, _, err := component(r.Ref, "requestBodies")
+	if err != nil {
+		return nil, err
+	}
+	if resolved, ok := d.Components.RequestBodies[name]; ok {
+		return resolved, nil
+	}
+	return nil, fmt.Errorf("reference %s not found", r.Ref)
+}
+
+// example follows e's reference, if it has one
+func (d *Document) example(e *Example) (*Example, error) {
+	if e.Ref == "" {
+		return e, nil
+	}
+	name, _, err := component(e.Ref, "examples")
+	if err != nil {
+		return nil, err
+	}
+	if resolved, ok := d.Components.Examples[name]; ok {
+		return resolved, nil
+	}
+	return nil, fmt.Errorf("reference %s not found", e.Ref)
+}
@@ -0,0 +1,53 @@
+package openapi
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLoadFile(t *testing.T) {
+	doc, err := LoadFile("testdata/petstore.json")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if len(doc.Paths) != 2 {
+		t.Errorf("expected 2 paths, got %d", len(doc.Paths))
+	}
+	if ops := doc.Paths["/pets"].Operations(); len(ops) != 2 || ops["GET"].OperationID != "listPets" {
+		t.Errorf("/pets: expected GET and POST, got %v", ops)
+	}
+	if len(doc.Components.Schemas) != 3 {
+		t.Errorf("expected 3 schemas, got %d", len(doc.Components.Schemas))
+	}
+
+	if _, err := Load(strings.NewReader(`{"swagger": "2.0"}`)); err == nil {
+		t.Error("expected an error for a Swagger 2 document")
+	}
+	if _, err := Load(strings.NewReader("openapi: 3.0.3\ninfo:\n  title: Pets\n")); err != errYAML {
+		t.Errorf("expected %v for a YAML document, got %v", errYAML, err)
+	}
+	if _, err := LoadFile("testdata/missing.json"); err == nil {
+		t.Error("expected an error for a missing file")
+	}
+}
+
+func TestResolve(t *testing.T) {
+	doc, err := LoadFile("testdata/petstore.json")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	s, err := doc.Resolve("#/components/schemas/NewPet/properties/contact")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if s.Format != "email" {
+		t.Errorf("expected the contact schema, got %+v", s)
+	}
+
+	for _, ref := range []string{"#/components/schemas/Missing", "#/components/responses/Error", "#/definitions/Pet"} {
+		if _, err := doc.Resolve(ref); err == nil {
+			t.Errorf("%s: expected an error", ref)
+		}
+	}
+}
@@ -0,0 +1,119 @@
+package lorem
+
+import (
+	"reflect"
+	"strings"
+)
+
+// WithOnlyZero only fills fields that are still their zero value, so values
+// set up before calling Fill are kept. Structs that are partly set are
+// filled in where they are zero.
+func WithOnlyZero() Option {
+	return func(g *Generator) {
+		g.onlyZero = true
+	}
+}
+
+// WithOnly only fills the fields at the given paths (dotted field names, as
+// for WithSpecs) and anything inside them. Structs leading to those paths
+// are allocated where needed but otherwise left alone.
+func WithOnly(paths ...string) Option {
+	return func(g *Generator) {
+		g.only = append(g.only, paths...)
+	}
+}
+
+// WithExclude leaves the fields at the given paths, and
+// anything inside them, as they are
+func WithExclude(paths ...string) Option {
+	return func(g *Generator) {
+		g.exclude = append(g.exclude, paths...)
+	}
+}
+
+type partialAction int
+
+const (
+	fillField partialAction = iota
+	skipField
+	// descend into the field to fill some of what's inside
+	descendField
+)
+
+// partial decides what to do with the field at path, which is
+// at alias using the WithFieldNames names
+func (g *Generator) partial(path, alias string, field reflect.Value) partialAction {
+	if pathIn(path, g.exclude) || pathIn(alias, g.exclude) {
+		return skipField
+	}
+	if len(g.only) > 0 && !pathIn(path, g.only) && !pathIn(alias, g.only) {
+		for _, p := range g.only {
+			if strings.HasPrefix(p, path+".") || strings.HasPrefix(p, alias+".") {
+				return descendField
+			}
+		}
+		return skipField
+	}
+	if g.onlyZero && !field.IsZero() {
+		if isPlainStruct(field.Type()) {
+			return descendField
+		}
+		return skipField
+	}
+	return fillField
+}
+
+// pathIn reports whether path is one of paths, or inside one of them
+func pathIn(path string, paths []string) bool {
+	for _, p := range paths {
+		if path == p || strings.HasPrefix(path, p+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// descend fills the fields inside field, which are
+// each checked with partial in turn
+func (g *Generator) descend(path, alias string, field reflect.Value) error {
+	switch field.Kind() {
+	case reflect.Ptr:
+		if field.IsNil() {
+			if field.Type().Elem().Kind() != reflect.Struct || !field.CanSet() {
+				return nil
+			}
+			field.Set(reflect.New(field.Type().Elem()))
+		}
+		return g.descend(path, alias, field.Elem())
+	case reflect.Struct:
+		return g.fillStruct(path, alias, field)
+	case reflect.Slice:
+		for i := 0; i < field.Len(); i++ {
+			if err := g.descend(path, alias, field.Index(i)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// isPlainStruct reports whether typ (or what it points to) is a struct
+// that fillRec fills field by field, rather than as a whole
+func isPlainStruct(typ reflect.Type) bool {
+	if typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	if typ.Kind() != reflect.Struct || typ == timeType || isNullType(typ) {
+		return false
+	}
+	if _, ok := registeredType(typ); ok {
+		return false
+	}
+	ptr := reflect.PtrTo(typ)
+	for _, iface := range []reflect.Type{fillerType, decoderType, textUnmarshalerType, jsonUnmarshalerType, scannerType} {
+		if ptr.Implements(iface) {
+			return false
+		}
+	}
+	return true
+}
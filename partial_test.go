@@ -0,0 +1,117 @@
+package lorem
+
+import (
+	"strings"
+	"testing"
+)
+
+type PartialAddress struct {
+	Street string
+	City   string
+}
+
+type PartialUser struct {
+	FirstName string `lorem:"firstname"`
+	LastName  string `lorem:"lastname"`
+	Email     string `lorem:"email,from=FirstName+LastName"`
+	Age       int
+	Address   PartialAddress
+	Previous  *PartialAddress
+	Tags      []string `lorem:"[2,3]word"`
+}
+
+func TestWithOnlyZero(t *testing.T) {
+	pu := PartialUser{
+		FirstName: "Ada",
+		Age:       36,
+		Address:   PartialAddress{City: "London"},
+		Tags:      []string{"kept"},
+	}
+	if err := Fill(&pu, WithOnlyZero()); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	if pu.FirstName != "Ada" {
+		t.Errorf("FirstName: expected %s, got %s", "Ada", pu.FirstName)
+	}
+	if pu.Age != 36 {
+		t.Errorf("Age: expected %d, got %d", 36, pu.Age)
+	}
+	if pu.LastName == "" {
+		t.Errorf("LastName: expected string not empty, got %s", pu.LastName)
+	}
+	if !strings.HasPrefix(pu.Email, "ada.") {
+		t.Errorf("Email: expected to be made from the set FirstName, got %s", pu.Email)
+	}
+	if pu.Address.City != "London" || pu.Address.Street == "" {
+		t.Errorf("Address: expected Street to be filled and City kept, got %+v", pu.Address)
+	}
+	if pu.Previous == nil || pu.Previous.City == "" {
+		t.Errorf("Previous: expected pointer to be filled, got %v", pu.Previous)
+	}
+	if len(pu.Tags) != 1 || pu.Tags[0] != "kept" {
+		t.Errorf("Tags: expected [kept], got %v", pu.Tags)
+	}
+}
+
+func TestWithOnly(t *testing.T) {
+	var pu PartialUser
+	if err := Fill(&pu, WithOnly("LastName", "Previous.City", "Address")); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	if pu.FirstName != "" || pu.Email != "" || pu.Age != 0 || pu.Tags != nil {
+		t.Errorf("expected only LastName, Previous.City and Address to be filled, got %+v", pu)
+	}
+	if pu.LastName == "" {
+		t.Errorf("LastName: expected string not empty, got %s", pu.LastName)
+	}
+	if pu.Address.Street == "" || pu.Address.City == "" {
+		t.Errorf("Address: expected everything inside to be filled, got %+v", pu.Address)
+	}
+	if pu.Previous == nil || pu.Previous.City == "" || pu.Previous.Street != "" {
+		t.Errorf("Previous: expected only City to be filled, got %+v", pu.Previous)
+	}
+}
+
+func TestWithExclude(t *testing.T) {
+	var pu PartialUser
+	if err := Fill(&pu, WithExclude("Age", "Address.City", "Previous")); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	if pu.Age != 0 {
+		t.Errorf("Age: expected %d, got %d", 0, pu.Age)
+	}
+	if pu.Address.City != "" || pu.Address.Street == "" {
+		t.Errorf("Address: expected only Street to be filled, got %+v", pu.Address)
+	}
+	if pu.Previous != nil {
+		t.Errorf("Previous: expected nil, got %+v", pu.Previous)
+	}
+	if pu.FirstName == "" || len(pu.Tags) < 2 {
+		t.Errorf("expected everything else to be filled, got %+v", pu)
+	}
+}
+
+func TestPartialSlices(t *testing.T) {
+	users := []PartialUser{{FirstName: "Ada"}, {FirstName: "Alan"}}
+	var ss struct {
+		Users []PartialUser
+	}
+	ss.Users = users
+	if err := Fill(&ss, WithOnlyZero()); err != nil {
+		t.Fatal(err.Error())
+	}
+	// slices that are set are kept as they are
+	if len(ss.Users) != 2 || ss.Users[0].LastName != "" {
+		t.Errorf("Users: expected to be kept, got %+v", ss.Users)
+	}
+
+	if err := Fill(&ss, WithOnly("Users.LastName")); err != nil {
+		t.Fatal(err.Error())
+	}
+	if len(ss.Users) != 2 || ss.Users[1].FirstName != "Alan" || ss.Users[1].LastName == "" {
+		t.Errorf("Users: expected LastName to be filled in each, got %+v", ss.Users)
+	}
+}
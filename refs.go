@@ -0,0 +1,290 @@
+package lorem
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// fieldRefs are the references a tag makes to sibling fields:
+// from=A+B derives the value from A and B, and after=A or
+// before=A generates a time after or before A (or between
+// them, with both)
+type fieldRefs struct {
+	from   []string
+	after  string
+	before string
+}
+
+// names returns every sibling field referred to
+func (r *fieldRefs) names() []string {
+	names := append([]string{}, r.from...)
+	if r.after != "" {
+		names = append(names, r.after)
+	}
+	if r.before != "" {
+		names = append(names, r.before)
+	}
+	return names
+}
+
+// parseRefs splits the references out of tag, returning the
+// rest of the tag and the references, or nil if there are none.
+// The kind can be left out, as in `lorem:"from=Title"`.
+func parseRefs(tag string) (string, *fieldRefs) {
+	args := strings.Split(tag, ",")
+	if args[0] == "" {
+		// literals can contain anything
+		return tag, nil
+	}
+	var refs *fieldRefs
+	var rest []string
+	for _, arg := range args {
+		key, value, ok := strings.Cut(arg, "=")
+		if !ok || (key != "from" && key != "after" && key != "before") {
+			rest = append(rest, arg)
+			continue
+		}
+		if refs == nil {
+			refs = &fieldRefs{}
+		}
+		switch key {
+		case "from":
+			refs.from = strings.Split(value, "+")
+		case "after":
+			refs.after = value
+		case "before":
+			refs.before = value
+		}
+	}
+	return strings.Join(rest, ","), refs
+}
+
+// fieldOrder returns the order to fill the fields of typ in, so every field
+// comes after the fields it refers to, along with each field's tag and
+// references. Fields are referred to, and named in errors, by their Go name
+// or their name in names. If a reference is unknown or there is a cycle it
+// returns the index of the field at fault and an error.
+func fieldOrder(typ reflect.Type, names, tags []string) ([]int, []*fieldRefs, int, error) {
+	refs := make([]*fieldRefs, len(tags))
+	for i, tag := range tags {
+		tags[i], refs[i] = parseRefs(tag)
+		if refs[i] == nil {
+			continue
+		}
+		// refer to fields by their Go names from here on
+		goName := func(name string) (string, error) {
+			j, ok := fieldIndex(typ, names, name)
+			if !ok {
+				return "", fmt.Errorf("reference to unknown field %s", name)
+			}
+			return typ.Field(j).Name, nil
+		}
+		var err error
+		for k, name := range refs[i].from {
+			if refs[i].from[k], err = goName(name); err != nil {
+				return nil, nil, i, err
+			}
+		}
+		for _, name := range []*string{&refs[i].after, &refs[i].before} {
+			if *name == "" {
+				continue
+			}
+			if *name, err = goName(*name); err != nil {
+				return nil, nil, i, err
+			}
+		}
+	}
+
+	const (
+		unvisited = iota
+		visiting
+		done
+	)
+	state := make([]int, len(tags))
+	order := make([]int, 0, len(tags))
+	var stack []string
+
+	var visit func(i int) (int, error)
+	visit = func(i int) (int, error) {
+		switch state[i] {
+		case done:
+			return -1, nil
+		case visiting:
+			name := names[i]
+			for len(stack) > 0 && stack[0] != name {
+				stack = stack[1:]
+			}
+			return i, fmt.Errorf("reference cycle %s -> %s", strings.Join(stack, " -> "), name)
+		}
+		state[i] = visiting
+		stack = append(stack, names[i])
+		if refs[i] != nil {
+			for _, name := range refs[i].names() {
+				sf, _ := typ.FieldByName(name)
+				if j, err := visit(sf.Index[0]); err != nil {
+					return j, err
+				}
+			}
+		}
+		stack = stack[:len(stack)-1]
+		state[i] = done
+		order = append(order, i)
+		return -1, nil
+	}
+
+	for i := range tags {
+		if i, err := visit(i); err != nil {
+			return nil, nil, i, err
+		}
+	}
+	return order, refs, -1, nil
+}
+
+// fieldIndex returns the index of the top level field of typ with
+// the Go name or name in names given, and whether there is one
+func fieldIndex(typ reflect.Type, names []string, name string) (int, bool) {
+	if sf, ok := typ.FieldByName(name); ok && len(sf.Index) == 1 {
+		return sf.Index[0], true
+	}
+	for i, n := range names {
+		if n == name {
+			return i, true
+		}
+	}
+	return -1, false
+}
+
+// fillFromRefs fills field using the values of the sibling fields in parent
+// it refers to. Values derived with from= are shaped by the tag's kind, so an
+// email made from FirstName+LastName looks like first.last@host.com; with no
+// kind the value is copied (or joined with spaces for several fields).
+func (g *Generator) fillFromRefs(tag string, refs *fieldRefs, field, parent reflect.Value) error {
+	if !field.CanSet() || tag == "-" {
+		return nil
+	}
+
+	if len(refs.from) > 0 {
+		parts := make([]string, len(refs.from))
+		for i, name := range refs.from {
+			v, err := refValue(parent, name)
+			if err != nil {
+				return err
+			}
+			if len(refs.from) == 1 && tag == "" && v.CanInterface() && v.Type().AssignableTo(field.Type()) {
+				field.Set(v)
+				return nil
+			}
+			parts[i] = fmt.Sprint(v)
+		}
+		return setValue(field, g.derive(strings.Split(tag, ",")[0], parts))
+	}
+
+	var t time.Time
+	// somewhere between a second and 30 days away, or inside the window
+	// when there is both an after and a before
+	month := int64(30 * 24 * time.Hour / time.Second)
+	switch {
+	case refs.after != "" && refs.before != "":
+		after, err := refTimeValue(parent, refs.after)
+		if err != nil {
+			return err
+		}
+		before, err := refTimeValue(parent, refs.before)
+		if err != nil {
+			return err
+		}
+		span := int64(before.Sub(after) / time.Second)
+		if span < 2 {
+			return fmt.Errorf("no time after %s (%s) and before %s (%s)",
+				refs.after, after.Format(time.RFC3339), refs.before, before.Format(time.RFC3339))
+		}
+		t = after.Add(time.Duration(1+g.rand.Int63n(span-1)) * time.Second)
+	case refs.after != "":
+		after, err := refTimeValue(parent, refs.after)
+		if err != nil {
+			return err
+		}
+		t = after.Add(time.Duration(1+g.rand.Int63n(month)) * time.Second)
+	default:
+		before, err := refTimeValue(parent, refs.before)
+		if err != nil {
+			return err
+		}
+		t = before.Add(-time.Duration(1+g.rand.Int63n(month)) * time.Second)
+	}
+	if field.Kind() == reflect.String {
+		field.SetString(t.Format(time.RFC3339))
+		return nil
+	}
+	return setValue(field, t)
+}
+
+// derive makes a value of the given kind out of parts
+func (g *Generator) derive(kind string, parts []string) string {
+	joined := strings.Join(parts, " ")
+	switch kind {
+	case "email":
+		return strings.Join(simpleWords(joined), ".") + "@" + g.Host()
+	case "host":
+		return strings.Join(simpleWords(joined), "") + ".com"
+	case "url":
+		return "http://www." + strings.Join(simpleWords(joined), "") + ".com"
+	case "readablepath":
+		return ReadablePath(joined)
+	}
+	return joined
+}
+
+// simpleWords splits s into lower case words of only letters and numbers
+func simpleWords(s string) []string {
+	words := strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+	return words
+}
+
+// refValue returns the value of the named field of parent,
+// following pointers
+func refValue(parent reflect.Value, name string) (reflect.Value, error) {
+	v := parent.FieldByName(name)
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return v, fmt.Errorf("referenced field %s is nil", name)
+		}
+		v = v.Elem()
+	}
+	return v, nil
+}
+
+// refTimeValue returns the time held by the named field of parent, which
+// has to be filled: a zero time, left out by WithOnly or WithExclude say,
+// would give times in the year 1
+func refTimeValue(parent reflect.Value, name string) (time.Time, error) {
+	v, err := refValue(parent, name)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if v.IsZero() {
+		return time.Time{}, fmt.Errorf("referenced field %s is not filled", name)
+	}
+	t, ok := refTime(v)
+	if !ok {
+		return time.Time{}, fmt.Errorf("referenced field %s is not a time", name)
+	}
+	return t, nil
+}
+
+// refTime returns the time held by v, which may also be an RFC3339 string
+func refTime(v reflect.Value) (time.Time, bool) {
+	switch {
+	case v.Type() == timeType && v.CanInterface():
+		return v.Interface().(time.Time), true
+	case v.Kind() == reflect.String:
+		t, err := time.Parse(time.RFC3339, v.String())
+		return t, err == nil
+	}
+	return time.Time{}, false
+}
@@ -0,0 +1,149 @@
+package lorem
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+type StructWithRefs struct {
+	// referring fields come first, to check the order is worked out
+	Email     string     `lorem:"email,from=FirstName+LastName"`
+	Slug      string     `lorem:"readablepath,from=Title"`
+	Copy      string     `lorem:"from=Title"`
+	FullName  *string    `lorem:"from=FirstName+LastName"`
+	EndDate   time.Time  `lorem:"time,after=StartDate"`
+	EndString string     `lorem:"time,after=StartDate"`
+	Before    *time.Time `lorem:"time,before=StartDate"`
+	StartDate time.Time
+	FirstName string `lorem:"firstname"`
+	LastName  string `lorem:"lastname"`
+	Title     string `lorem:"sentence,3,5"`
+	Literal   string `lorem:",from=nothing"`
+}
+
+func TestRefs(t *testing.T) {
+	for i := 0; i < 20; i++ {
+		var ss StructWithRefs
+		if err := Fill(&ss); err != nil {
+			t.Fatal(err.Error())
+		}
+
+		expected := strings.ToLower(ss.FirstName + "." + ss.LastName + "@")
+		if !strings.HasPrefix(ss.Email, expected) {
+			t.Errorf("Email: expected %s..., got %s", expected, ss.Email)
+		}
+		if ss.Slug != ReadablePath(ss.Title) {
+			t.Errorf("Slug: expected %s, got %s", ReadablePath(ss.Title), ss.Slug)
+		}
+		if ss.Copy != ss.Title {
+			t.Errorf("Copy: expected %s, got %s", ss.Title, ss.Copy)
+		}
+		if ss.FullName == nil || *ss.FullName != ss.FirstName+" "+ss.LastName {
+			t.Errorf("FullName: expected %s %s, got %v", ss.FirstName, ss.LastName, ss.FullName)
+		}
+		if !ss.EndDate.After(ss.StartDate) {
+			t.Errorf("EndDate: expected %s to be after %s", ss.EndDate, ss.StartDate)
+		}
+		if end, err := time.Parse(time.RFC3339, ss.EndString); err != nil || !end.After(ss.StartDate) {
+			t.Errorf("EndString: expected %s to be after %s", ss.EndString, ss.StartDate)
+		}
+		if ss.Before == nil || !ss.Before.Before(ss.StartDate) {
+			t.Errorf("Before: expected %v to be before %s", ss.Before, ss.StartDate)
+		}
+		if ss.Literal != "from=nothing" {
+			t.Errorf("Literal: expected %s, got %s", "from=nothing", ss.Literal)
+		}
+	}
+}
+
+func TestRefsNested(t *testing.T) {
+	var ss struct {
+		Sub  StructWithRefs
+		Subs []*StructWithRefs `lorem:"[2,2]"`
+	}
+	if err := Fill(&ss); err != nil {
+		t.Fatal(err.Error())
+	}
+	if ss.Sub.Copy != ss.Sub.Title {
+		t.Errorf("Sub.Copy: expected %s, got %s", ss.Sub.Title, ss.Sub.Copy)
+	}
+	for _, sub := range ss.Subs {
+		if !sub.EndDate.After(sub.StartDate) {
+			t.Errorf("Subs.EndDate: expected %s to be after %s", sub.EndDate, sub.StartDate)
+		}
+	}
+}
+
+func TestRefsErrors(t *testing.T) {
+	var cycle struct {
+		A string `lorem:"from=C"`
+		B string `lorem:"from=A"`
+		C string `lorem:"from=B"`
+	}
+	err := Fill(&cycle)
+	if err == nil || !strings.Contains(err.Error(), "reference cycle A -> C -> B -> A") {
+		t.Errorf("Expected reference cycle error, got %v", err)
+	}
+	if perr, ok := err.(*ParseError); !ok || perr.FieldName != "A" {
+		t.Errorf("Expected ParseError for field A, got %#v", err)
+	}
+
+	var unknown struct {
+		A string `lorem:"email,from=Nope"`
+	}
+	if err := Fill(&unknown); err == nil || !strings.Contains(err.Error(), "unknown field Nope") {
+		t.Errorf("Expected unknown field error, got %v", err)
+	}
+
+	var notTime struct {
+		A string
+		B time.Time `lorem:"time,after=A"`
+	}
+	if err := Fill(&notTime); err == nil || !strings.Contains(err.Error(), "not a time") {
+		t.Errorf("Expected not a time error, got %v", err)
+	}
+
+	var backwards struct {
+		Start time.Time `lorem:"time"`
+		End   time.Time `lorem:"time,before=Start"`
+		Mid   time.Time `lorem:"time,after=Start,before=End"`
+	}
+	if err := Fill(&backwards); err == nil || !strings.Contains(err.Error(), "no time after Start") {
+		t.Errorf("Expected an empty window error, got %v", err)
+	}
+}
+
+func TestRefsWindow(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		var w struct {
+			Start time.Time `lorem:"time"`
+			End   time.Time `lorem:"time,after=Start"`
+			Mid   string    `lorem:"time,after=Start,before=End"`
+		}
+		if err := Fill(&w); err != nil {
+			t.Fatal(err.Error())
+		}
+		mid, err := time.Parse(time.RFC3339, w.Mid)
+		if err != nil || !mid.After(w.Start) || !mid.Before(w.End) {
+			t.Fatalf("Mid: expected a time between %v and %v, got %q", w.Start, w.End, w.Mid)
+		}
+	}
+}
+
+func TestRefsUnfilled(t *testing.T) {
+	type Event struct {
+		Start time.Time `lorem:"time"`
+		End   time.Time `lorem:"time,after=Start"`
+	}
+	for name, opt := range map[string]Option{
+		"WithOnly":    WithOnly("End"),
+		"WithExclude": WithExclude("Start"),
+	} {
+		var e Event
+		err := Fill(&e, opt)
+		if err == nil || !strings.Contains(err.Error(), "referenced field Start is not filled") {
+			t.Errorf("%s: expected an error for a time after a zero time, got %v and %v", name, err, e.End)
+		}
+	}
+}
@@ -0,0 +1,415 @@
+package schema
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"time"
+	"unicode/utf8"
+
+	lorem "github.com/axiomzen/golorem"
+)
+
+const (
+	// shallowDepth is how deeply values nest before optional properties
+	// are left out and arrays are kept to their fewest items, so
+	// recursive schemas end
+	shallowDepth = 6
+	// maxDepth is how deeply values can nest at all
+	maxDepth = 64
+	// tries is how many strings are tried to fit a length
+	tries = 100
+)
+
+// errNever is returned for the schema false
+var errNever = errors.New("schema false has no values")
+
+// Resolver returns the schema a $ref points to
+type Resolver func(ref string) (*Schema, error)
+
+// Generator generates values conforming to schemas
+type Generator struct {
+	lorem   *lorem.Generator
+	resolve Resolver
+}
+
+// NewGenerator returns a Generator using g for random values and resolve
+// for references, which can be nil if the schemas have none
+func NewGenerator(g *lorem.Generator, resolve Resolver) *Generator {
+	return &Generator{lorem: g, resolve: resolve}
+}
+
+// Generate returns a random value conforming to s, with references
+// resolved against s. Values are of the types encoding/json decodes
+// into: map[string]interface{}, []interface{}, string, float64, bool or nil.
+func Generate(s *Schema, opts ...lorem.Option) (interface{}, error) {
+	return NewGenerator(lorem.NewGenerator(opts...), s.Resolve).Generate(s)
+}
+
+// GenerateJSON returns a random JSON document conforming to s
+func GenerateJSON(s *Schema, opts ...lorem.Option) ([]byte, error) {
+	v, err := Generate(s, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(v)
+}
+
+// Generate returns a random value conforming to s
+func (sg *Generator) Generate(s *Schema) (interface{}, error) {
+	return sg.value(s, 0)
+}
+
+func (sg *Generator) value(s *Schema, depth int) (interface{}, error) {
+	if depth > maxDepth {
+		return nil, fmt.Errorf("schema nests deeper than %d", maxDepth)
+	}
+	if s == nil {
+		// anything goes
+		return sg.lorem.Word(2, 10), nil
+	}
+	if s.never {
+		return nil, errNever
+	}
+	if s.Ref != "" {
+		if sg.resolve == nil {
+			return nil, fmt.Errorf("cannot resolve reference %s", s.Ref)
+		}
+		target, err := sg.resolve(s.Ref)
+		if err != nil {
+			return nil, err
+		}
+		return sg.value(target, depth+1)
+	}
+	if len(s.AllOf) > 0 {
+		merged, err := sg.allOf(s, depth)
+		if err != nil {
+			return nil, err
+		}
+		return sg.value(merged, depth)
+	}
+	if s.hasConst {
+		return s.Const, nil
+	}
+	if s.Nullable && sg.lorem.IntRange(0, 4) == 0 {
+		return nil, nil
+	}
+	if len(s.Examples) > 0 {
+		return s.Examples[sg.lorem.IntRange(0, len(s.Examples))], nil
+	}
+	if s.Example != nil {
+		return s.Example, nil
+	}
+	if len(s.Enum) > 0 {
+		return s.Enum[sg.lorem.IntRange(0, len(s.Enum))], nil
+	}
+	if len(s.OneOf) > 0 {
+		return sg.value(s.OneOf[sg.lorem.IntRange(0, len(s.OneOf))], depth)
+	}
+	if len(s.AnyOf) > 0 {
+		return sg.value(s.AnyOf[sg.lorem.IntRange(0, len(s.AnyOf))], depth)
+	}
+
+	switch typ := sg.typeOf(s); typ {
+	case "null":
+		return nil, nil
+	case "boolean":
+		return sg.lorem.IntRange(0, 2) == 1, nil
+	case "integer":
+		return sg.integer(s)
+	case "number":
+		return sg.number(s)
+	case "string":
+		return sg.str(s)
+	case "array":
+		return sg.array(s, depth)
+	case "object":
+		return sg.object(s, depth)
+	default:
+		return nil, fmt.Errorf("unknown type %s", typ)
+	}
+}
+
+// allOf returns s with the schemas in its allOf merged in. Properties and
+// required properties are combined, and otherwise the first schema
+// to use a keyword wins.
+func (sg *Generator) allOf(s *Schema, depth int) (*Schema, error) {
+	merged := *s
+	merged.AllOf = nil
+	merged.Properties = map[string]*Schema{}
+	for name, prop := range s.Properties {
+		merged.Properties[name] = prop
+	}
+	merged.Required = append([]string{}, s.Required...)
+
+	for _, sub := range s.AllOf {
+		for sub != nil && sub.Ref != "" {
+			if depth++; depth > maxDepth || sg.resolve == nil {
+				return nil, fmt.Errorf("cannot resolve reference %s", sub.Ref)
+			}
+			var err error
+			if sub, err = sg.resolve(sub.Ref); err != nil {
+				return nil, err
+			}
+		}
+		if sub == nil {
+			continue
+		}
+		if sub.never {
+			return nil, errNever
+		}
+		if len(sub.AllOf) > 0 {
+			var err error
+			if sub, err = sg.allOf(sub, depth+1); err != nil {
+				return nil, err
+			}
+		}
+
+		for name, prop := range sub.Properties {
+			if _, ok := merged.Properties[name]; !ok {
+				merged.Properties[name] = prop
+			}
+		}
+		merged.Required = append(merged.Required, sub.Required...)
+		if !merged.hasConst && sub.hasConst {
+			merged.hasConst, merged.Const = true, sub.Const
+		}
+		dst, src := reflect.ValueOf(&merged).Elem(), reflect.ValueOf(sub).Elem()
+		for i := 0; i < dst.NumField(); i++ {
+			if field := dst.Field(i); field.CanSet() && field.IsZero() {
+				field.Set(src.Field(i))
+			}
+		}
+	}
+	if len(merged.Properties) == 0 {
+		merged.Properties = nil
+	}
+	if len(merged.Required) == 0 {
+		merged.Required = nil
+	}
+	return &merged, nil
+}
+
+// typeOf picks one of the schema's types, or works out
+// the type from its keywords when it has none
+func (sg *Generator) typeOf(s *Schema) string {
+	switch {
+	case len(s.Type) > 0:
+		return s.Type[sg.lorem.IntRange(0, len(s.Type))]
+	case s.Properties != nil || s.Required != nil:
+		return "object"
+	case s.Items != nil || s.MinItems != nil || s.MaxItems != nil:
+		return "array"
+	case s.Minimum != nil || s.Maximum != nil || s.ExclusiveMinimum != nil || s.ExclusiveMaximum != nil:
+		return "number"
+	}
+	return "string"
+}
+
+// fraction returns a random number in [0, 1)
+func (sg *Generator) fraction() float64 {
+	return float64(sg.lorem.IntRange(0, 1<<53)) / (1 << 53)
+}
+
+// between returns a random integer in [lo, hi]
+func (sg *Generator) between(lo, hi int) int {
+	if hi <= lo {
+		return lo
+	}
+	return lo + sg.lorem.IntRange(0, hi-lo+1)
+}
+
+// span returns lo and hi, filling in whichever is missing (NaN)
+// so the range is about size wide, starting at 0 where possible
+func span(lo, hi, size float64) (float64, float64) {
+	switch {
+	case math.IsNaN(lo) && math.IsNaN(hi):
+		return 0, size
+	case math.IsNaN(lo):
+		if hi >= 0 {
+			return 0, hi
+		}
+		return hi - size, hi
+	case math.IsNaN(hi):
+		if lo <= 0 {
+			return lo, size
+		}
+		return lo, lo + size
+	}
+	return lo, hi
+}
+
+func (sg *Generator) integer(s *Schema) (interface{}, error) {
+	lo, hi := math.NaN(), math.NaN()
+	if s.Minimum != nil {
+		lo = math.Ceil(*s.Minimum)
+	}
+	if s.ExclusiveMinimum != nil && !(lo > *s.ExclusiveMinimum) {
+		lo = math.Floor(*s.ExclusiveMinimum) + 1
+	}
+	if s.Maximum != nil {
+		hi = math.Floor(*s.Maximum)
+	}
+	if s.ExclusiveMaximum != nil && !(hi < *s.ExclusiveMaximum) {
+		hi = math.Ceil(*s.ExclusiveMaximum) - 1
+	}
+	lo, hi = span(lo, hi, 1000)
+	if lo > hi {
+		return nil, fmt.Errorf("no integer between %v and %v", lo, hi)
+	}
+	// keep the range to what an int can count
+	hi = math.Min(hi, lo+(1<<53))
+	return lo + float64(sg.between(0, int(hi-lo))), nil
+}
+
+func (sg *Generator) number(s *Schema) (interface{}, error) {
+	lo, hi := math.NaN(), math.NaN()
+	if s.Minimum != nil {
+		lo = *s.Minimum
+	}
+	if s.ExclusiveMinimum != nil && !(lo > *s.ExclusiveMinimum) {
+		lo = math.Nextafter(*s.ExclusiveMinimum, math.Inf(1))
+	}
+	if s.Maximum != nil {
+		hi = *s.Maximum
+	}
+	if s.ExclusiveMaximum != nil && !(hi < *s.ExclusiveMaximum) {
+		hi = math.Nextafter(*s.ExclusiveMaximum, math.Inf(-1))
+	}
+	lo, hi = span(lo, hi, 1000)
+	if lo > hi {
+		return nil, fmt.Errorf("no number between %v and %v", lo, hi)
+	}
+	return lo + sg.fraction()*(hi-lo), nil
+}
+
+// format returns the generator for a string format, or nil for
+// formats that aren't known
+func (sg *Generator) format(name string) func() string {
+	g := sg.lorem
+	switch name {
+	case "email", "idn-email":
+		return g.Email
+	case "uri", "url", "iri", "uri-reference", "iri-reference":
+		return g.URL
+	case "uuid":
+		return g.UUID
+	case "hostname", "idn-hostname":
+		return g.Host
+	case "date-time":
+		return func() string { return g.Time().Format(time.RFC3339) }
+	case "date":
+		return func() string { return g.Time().Format(time.DateOnly) }
+	case "time":
+		return func() string { return g.Time().Format("15:04:05Z07:00") }
+	case "ipv4":
+		return sg.lorem.IPv4
+	case "ipv6":
+		return sg.lorem.IPv6
+	}
+	return nil
+}
+
+func (sg *Generator) str(s *Schema) (interface{}, error) {
+	lo, hi := 0, math.MaxInt
+	if s.MinLength != nil {
+		lo = *s.MinLength
+	}
+	if s.MaxLength != nil {
+		hi = *s.MaxLength
+	}
+	if lo < 0 || lo > hi {
+		return nil, fmt.Errorf("no string between %d and %d long", lo, hi)
+	}
+
+	gen := sg.format(s.Format)
+	if s.Pattern != "" {
+		re, err := parsePattern(s.Pattern)
+		if err != nil {
+			return nil, err
+		}
+		gen = func() string { return sg.pattern(re) }
+	}
+	if gen == nil {
+		if s.MinLength == nil && s.MaxLength == nil {
+			return sg.lorem.Word(2, 10), nil
+		}
+		hi = min(hi, lo+20)
+		return sg.lorem.Text(sg.between(lo, hi)), nil
+	}
+
+	for try := 0; try < tries; try++ {
+		str := gen()
+		if n := utf8.RuneCountInString(str); n >= lo && n <= hi {
+			return str, nil
+		}
+	}
+	return nil, fmt.Errorf("could not generate a string between %d and %d long", lo, hi)
+}
+
+func (sg *Generator) array(s *Schema, depth int) (interface{}, error) {
+	lo, hi := 1, 5
+	switch {
+	case s.MinItems != nil && s.MaxItems != nil:
+		lo, hi = *s.MinItems, *s.MaxItems
+	case s.MinItems != nil:
+		lo, hi = *s.MinItems, *s.MinItems+4
+	case s.MaxItems != nil:
+		lo, hi = min(1, *s.MaxItems), *s.MaxItems
+	}
+	if lo < 0 || lo > hi {
+		return nil, fmt.Errorf("no array of between %d and %d items", lo, hi)
+	}
+	n := sg.between(lo, hi)
+	if depth >= shallowDepth {
+		n = 0
+		if s.MinItems != nil {
+			n = lo
+		}
+	}
+
+	items := make([]interface{}, n)
+	for i := range items {
+		v, err := sg.value(s.Items, depth+1)
+		if err != nil {
+			return nil, err
+		}
+		items[i] = v
+	}
+	return items, nil
+}
+
+func (sg *Generator) object(s *Schema, depth int) (interface{}, error) {
+	required := map[string]bool{}
+	for _, name := range s.Required {
+		required[name] = true
+	}
+	// sorted, so values are reproducible with a seed
+	names := make([]string, 0, len(s.Properties)+len(required))
+	for name := range s.Properties {
+		names = append(names, name)
+	}
+	for name := range required {
+		if _, ok := s.Properties[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	obj := map[string]interface{}{}
+	for _, name := range names {
+		// optional properties are there about half the time
+		if !required[name] && (depth >= shallowDepth || sg.lorem.IntRange(0, 2) == 0) {
+			continue
+		}
+		v, err := sg.value(s.Properties[name], depth+1)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		obj[name] = v
+	}
+	return obj, nil
+}
@@ -0,0 +1,222 @@
+package schema
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"testing"
+
+	lorem "github.com/axiomzen/golorem"
+)
+
+func TestGenerate(t *testing.T) {
+	s, err := LoadFile("testdata/user.schema.json")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	uuid := regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+	code := regexp.MustCompile(`^[A-Z]{3}-\d{4}$`)
+	zip := regexp.MustCompile(`^[0-9]{5}$`)
+	seen := map[string]int{}
+	for seed := int64(0); seed < 100; seed++ {
+		v, err := Generate(s, lorem.WithSeed(seed))
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		user := v.(map[string]interface{})
+		for name := range user {
+			seen[name]++
+		}
+
+		if id, _ := user["id"].(string); !uuid.MatchString(id) {
+			t.Errorf("id: expected a uuid, got %v", user["id"])
+		}
+		if email, _ := user["email"].(string); email == "" {
+			t.Errorf("email: expected a string, got %v", user["email"])
+		} else if _, err := mail.ParseAddress(email); err != nil {
+			t.Errorf("email: expected an email, got %q", email)
+		}
+		if website, ok := user["website"].(string); ok {
+			if _, err := url.ParseRequestURI(website); err != nil {
+				t.Errorf("website: expected a url, got %q", website)
+			}
+		}
+		if name, _ := user["name"].(string); len(name) < 3 || len(name) > 12 {
+			t.Errorf("name: expected 3 to 12 characters, got %q", name)
+		}
+		if c, ok := user["code"]; ok && !code.MatchString(c.(string)) {
+			t.Errorf("code: expected to match the pattern, got %q", c)
+		}
+		if age, _ := user["age"].(float64); age < 18 || age >= 100 || age != float64(int(age)) {
+			t.Errorf("age: expected an integer from 18 to 99, got %v", user["age"])
+		}
+		if score, ok := user["score"].(float64); ok && (score <= 0 || score > 1) {
+			t.Errorf("score: expected above 0 up to 1, got %v", score)
+		}
+		if role := user["role"]; role != "admin" && role != "user" && role != "guest" {
+			t.Errorf("role: expected one of the enum, got %v", role)
+		}
+		if version, ok := user["version"]; ok && version != float64(2) {
+			t.Errorf("version: expected 2, got %v", version)
+		}
+		if nick, ok := user["nickname"]; ok && nick != nil && len(nick.(string)) > 8 {
+			t.Errorf("nickname: expected null or at most 8 characters, got %q", nick)
+		}
+		if tags, _ := user["tags"].([]interface{}); len(tags) < 1 || len(tags) > 3 {
+			t.Errorf("tags: expected 1 to 3 tags, got %v", user["tags"])
+		}
+		address, _ := user["address"].(map[string]interface{})
+		if z, _ := address["zip"].(string); !zip.MatchString(z) || address["city"] == nil {
+			t.Errorf("address: expected a city and zip, got %v", user["address"])
+		}
+		switch contact := user["contact"].(type) {
+		case nil, string:
+		case float64:
+			if contact < 1 {
+				t.Errorf("contact: expected at least 1, got %v", contact)
+			}
+		default:
+			t.Errorf("contact: expected an email or number, got %v", contact)
+		}
+	}
+
+	for _, name := range []string{"website", "code", "score", "active", "nickname", "contact", "manager"} {
+		if seen[name] < 20 || seen[name] > 80 {
+			t.Errorf("%s: expected optional property about half of 100 times, got %d", name, seen[name])
+		}
+	}
+}
+
+func TestGenerateJSONSeeds(t *testing.T) {
+	s, err := LoadFile("testdata/user.schema.json")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	a, err := GenerateJSON(s, lorem.WithSeed(7))
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	b, err := GenerateJSON(s, lorem.WithSeed(7))
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if !bytes.Equal(a, b) {
+		t.Errorf("expected the same document for the same seed, got %s and %s", a, b)
+	}
+	if !json.Valid(a) {
+		t.Errorf("expected valid JSON, got %s", a)
+	}
+}
+
+func TestGenerateErrors(t *testing.T) {
+	tests := map[string]string{
+		"false": "false schema",
+		`{"type": "integer", "minimum": 5, "maximum": 4}`:       "empty integer range",
+		`{"type": "string", "minLength": 5, "maxLength": 4}`:    "empty length range",
+		`{"type": "string", "format": "email", "maxLength": 3}`: "short email",
+		`{"type": "string", "minLength": -3}`:                   "negative min length",
+		`{"type": "string", "minLength": -5, "maxLength": -2}`:  "negative lengths",
+		`{"type": "array", "minItems": 3, "maxItems": 1}`:       "empty item range",
+		`{"type": "array", "maxItems": -2}`:                     "negative max items",
+		`{"type": "widget"}`:                                    "unknown type",
+		`{"$ref": "#/$defs/missing"}`:                           "missing reference",
+		`{"properties": {"a": false}, "required": ["a"]}`:       "impossible property",
+	}
+	for doc, name := range tests {
+		s, err := Parse([]byte(doc))
+		if err != nil {
+			t.Fatalf("%s: %s", name, err)
+		}
+		if v, err := Generate(s); err == nil {
+			t.Errorf("%s: expected an error, got %v", name, v)
+		}
+	}
+}
+
+func TestGenerateRecursive(t *testing.T) {
+	s, err := Parse([]byte(`{
+		"type": "object",
+		"required": ["name", "children"],
+		"properties": {
+			"name": {"type": "string"},
+			"children": {"type": "array", "items": {"$ref": "#"}}
+		}
+	}`))
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	for seed := int64(0); seed < 20; seed++ {
+		if _, err := Generate(s, lorem.WithSeed(seed)); err != nil {
+			t.Fatal(err.Error())
+		}
+	}
+}
+
+func TestGenerateAllOf(t *testing.T) {
+	s, err := Parse([]byte(`{
+		"$defs": {
+			"named": {"type": "object", "required": ["name"], "properties": {"name": {"type": "string"}}},
+			"aged": {"required": ["age"], "properties": {"age": {"type": "integer", "minimum": 1, "maximum": 9}}}
+		},
+		"allOf": [{"$ref": "#/$defs/named"}, {"$ref": "#/$defs/aged"}],
+		"required": ["id"],
+		"properties": {"id": {"format": "uuid"}}
+	}`))
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	for seed := int64(0); seed < 20; seed++ {
+		v, err := Generate(s, lorem.WithSeed(seed))
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		obj := v.(map[string]interface{})
+		if obj["id"] == nil || obj["name"] == nil {
+			t.Errorf("expected id and name, got %v", obj)
+		}
+		if age, _ := obj["age"].(float64); age < 1 || age > 9 {
+			t.Errorf("age: expected 1 to 9, got %v", obj["age"])
+		}
+	}
+	if len(s.Properties) != 1 || len(s.Required) != 1 {
+		t.Errorf("expected the schema to be left as it was, got %+v", s)
+	}
+}
+
+func TestGenerateExamples(t *testing.T) {
+	s, err := Parse([]byte(`{
+		"type": "object",
+		"required": ["a", "b", "c"],
+		"properties": {
+			"a": {"type": "string", "examples": ["x", "y"]},
+			"b": {"type": "integer", "example": 42},
+			"c": {"type": "string", "nullable": true}
+		}
+	}`))
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	nulls := 0
+	for seed := int64(0); seed < 100; seed++ {
+		v, err := Generate(s, lorem.WithSeed(seed))
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		obj := v.(map[string]interface{})
+		if obj["a"] != "x" && obj["a"] != "y" {
+			t.Errorf("a: expected one of the examples, got %v", obj["a"])
+		}
+		if obj["b"] != float64(42) {
+			t.Errorf("b: expected the example, got %v", obj["b"])
+		}
+		if obj["c"] == nil {
+			nulls++
+		}
+	}
+	if nulls < 10 || nulls > 45 {
+		t.Errorf("c: expected to be null sometimes, got %d of 100", nulls)
+	}
+}
@@ -0,0 +1,99 @@
+package schema
+
+import (
+	"regexp/syntax"
+	"strings"
+)
+
+// maxRepeat is the most times an unbounded repetition such
+// as a* or a{2,} repeats past its minimum
+const maxRepeat = 4
+
+// parsePattern parses a pattern keyword's regular expression
+func parsePattern(expr string) (*syntax.Regexp, error) {
+	re, err := syntax.Parse(expr, syntax.Perl)
+	if err != nil {
+		return nil, err
+	}
+	return re, nil
+}
+
+// pattern generates a string matching re
+func (sg *Generator) pattern(re *syntax.Regexp) string {
+	var b strings.Builder
+	sg.writePattern(&b, re)
+	return b.String()
+}
+
+func (sg *Generator) writePattern(b *strings.Builder, re *syntax.Regexp) {
+	switch re.Op {
+	case syntax.OpLiteral:
+		b.WriteString(string(re.Rune))
+	case syntax.OpCharClass:
+		b.WriteRune(sg.classRune(re.Rune))
+	case syntax.OpAnyChar, syntax.OpAnyCharNotNL:
+		b.WriteByte(byte('a' + sg.lorem.IntRange(0, 26)))
+	case syntax.OpCapture:
+		sg.writePattern(b, re.Sub[0])
+	case syntax.OpStar:
+		sg.repeat(b, re.Sub[0], 0, -1)
+	case syntax.OpPlus:
+		sg.repeat(b, re.Sub[0], 1, -1)
+	case syntax.OpQuest:
+		sg.repeat(b, re.Sub[0], 0, 1)
+	case syntax.OpRepeat:
+		sg.repeat(b, re.Sub[0], re.Min, re.Max)
+	case syntax.OpConcat:
+		for _, sub := range re.Sub {
+			sg.writePattern(b, sub)
+		}
+	case syntax.OpAlternate:
+		sg.writePattern(b, re.Sub[sg.lorem.IntRange(0, len(re.Sub))])
+	}
+	// anchors, word boundaries and empty matches write nothing
+}
+
+// repeat writes re between min and max times, where a max
+// of -1 means any number of times
+func (sg *Generator) repeat(b *strings.Builder, re *syntax.Regexp, min, max int) {
+	if max < 0 {
+		max = min + maxRepeat
+	}
+	for n := sg.between(min, max); n > 0; n-- {
+		sg.writePattern(b, re)
+	}
+}
+
+// classRune picks a rune from the class given as pairs of inclusive
+// ranges, preferring printable ASCII so negated classes such as [^,]
+// don't produce control characters
+func (sg *Generator) classRune(ranges []rune) rune {
+	var printable []rune
+	for i := 0; i+1 < len(ranges); i += 2 {
+		lo, hi := max(ranges[i], ' '), min(ranges[i+1], '~')
+		if lo <= hi {
+			printable = append(printable, lo, hi)
+		}
+	}
+	if len(printable) > 0 {
+		ranges = printable
+	}
+	if len(ranges) == 0 {
+		return 'a'
+	}
+
+	// weight each range by its size
+	total := 0
+	for i := 0; i+1 < len(ranges); i += 2 {
+		total += int(ranges[i+1]-ranges[i]) + 1
+	}
+	n := sg.lorem.IntRange(0, total)
+	for i := 0; i+1 < len(ranges); i += 2 {
+		size := int(ranges[i+1]-ranges[i]) + 1
+		if n < size {
+			return ranges[i] + rune(n)
+		}
+		n -= size
+	}
+	return ranges[0]
+}
@@ -0,0 +1,37 @@
+package schema
+
+import (
+	"regexp"
+	"testing"
+
+	lorem "github.com/axiomzen/golorem"
+)
+
+func TestPattern(t *testing.T) {
+	sg := NewGenerator(lorem.NewGenerator(lorem.WithSeed(1)), nil)
+	patterns := []string{
+		`^[A-Z]{3}-\d{4}$`,
+		`^(red|green|blue)$`,
+		`^[a-z]+@[a-z]+\.(com|org)$`,
+		`^[^,\s]{2,5}$`,
+		`^a*b?c+.x{2,}$`,
+		`(?i)^hello$`,
+		`\bword\b`,
+	}
+	for _, expr := range patterns {
+		re, err := parsePattern(expr)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		check := regexp.MustCompile(expr)
+		for i := 0; i < 50; i++ {
+			if str := sg.pattern(re); !check.MatchString(str) {
+				t.Errorf("%s: generated %q which doesn't match", expr, str)
+			}
+		}
+	}
+
+	if _, err := parsePattern(`[`); err == nil {
+		t.Error("expected an error for an invalid pattern")
+	}
+}
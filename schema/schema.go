@@ -0,0 +1,221 @@
+// Package schema generates random JSON documents conforming to a JSON Schema
+// (draft 2020-12), using the lorem generators for strings.
+//
+//	s, err := schema.LoadFile("user.schema.json")
+//	doc, err := schema.GenerateJSON(s, lorem.WithSeed(42))
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Schema is a JSON Schema, or one of its subschemas. Only the keywords
+// used to generate values are kept.
+type Schema struct {
+	Ref         string             `json:"$ref,omitempty"`
+	Defs        map[string]*Schema `json:"$defs,omitempty"`
+	Definitions map[string]*Schema `json:"definitions,omitempty"`
+
+	Type  Types         `json:"type,omitempty"`
+	Enum  []interface{} `json:"enum,omitempty"`
+	Const interface{}   `json:"const,omitempty"`
+
+	// objects
+	Properties map[string]*Schema `json:"properties,omitempty"`
+	Required   []string           `json:"required,omitempty"`
+
+	// strings
+	Pattern   string `json:"pattern,omitempty"`
+	Format    string `json:"format,omitempty"`
+	MinLength *int   `json:"minLength,omitempty"`
+	MaxLength *int   `json:"maxLength,omitempty"`
+
+	// numbers
+	Minimum          *float64 `json:"minimum,omitempty"`
+	Maximum          *float64 `json:"maximum,omitempty"`
+	ExclusiveMinimum *float64 `json:"exclusiveMinimum,omitempty"`
+	ExclusiveMaximum *float64 `json:"exclusiveMaximum,omitempty"`
+
+	// arrays
+	Items    *Schema `json:"items,omitempty"`
+	MinItems *int    `json:"minItems,omitempty"`
+	MaxItems *int    `json:"maxItems,omitempty"`
+
+	OneOf []*Schema `json:"oneOf,omitempty"`
+	AnyOf []*Schema `json:"anyOf,omitempty"`
+	AllOf []*Schema `json:"allOf,omitempty"`
+
+	// values used instead of generating one, Example
+	// being the OpenAPI 3.0 form of Examples
+	Examples []interface{} `json:"examples,omitempty"`
+	Example  interface{}   `json:"example,omitempty"`
+	// OpenAPI 3.0 for a type that can also be null
+	Nullable bool `json:"nullable,omitempty"`
+
+	// the schema false, which nothing conforms to
+	never bool
+	// the const keyword was given, even if as null
+	hasConst bool
+}
+
+// UnmarshalJSON decodes a schema, which can also be true or false.
+// OpenAPI 3.0's boolean exclusiveMinimum and exclusiveMaximum
+// are turned into their numeric form.
+func (s *Schema) UnmarshalJSON(data []byte) error {
+	var b bool
+	if err := json.Unmarshal(data, &b); err == nil {
+		*s = Schema{never: !b}
+		return nil
+	}
+	// decode without this method
+	type schema Schema
+	var raw struct {
+		schema
+		Const            json.RawMessage `json:"const"`
+		ExclusiveMinimum json.RawMessage `json:"exclusiveMinimum"`
+		ExclusiveMaximum json.RawMessage `json:"exclusiveMaximum"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	*s = Schema(raw.schema)
+	var err error
+	if s.ExclusiveMinimum, s.Minimum, err = exclusive(raw.ExclusiveMinimum, s.Minimum); err != nil {
+		return err
+	}
+	if s.ExclusiveMaximum, s.Maximum, err = exclusive(raw.ExclusiveMaximum, s.Maximum); err != nil {
+		return err
+	}
+	if raw.Const != nil {
+		s.hasConst = true
+		return json.Unmarshal(raw.Const, &s.Const)
+	}
+	return nil
+}
+
+// exclusive decodes an exclusive bound, which in OpenAPI 3.0 is true
+// to make the inclusive bound exclusive, returning both bounds
+func exclusive(data json.RawMessage, inclusive *float64) (*float64, *float64, error) {
+	if data == nil {
+		return nil, inclusive, nil
+	}
+	var b bool
+	if err := json.Unmarshal(data, &b); err == nil {
+		if b {
+			return inclusive, nil, nil
+		}
+		return nil, inclusive, nil
+	}
+	var f float64
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, nil, err
+	}
+	return &f, inclusive, nil
+}
+
+// Types is the type keyword, which can be one type or a list of them
+type Types []string
+
+// UnmarshalJSON decodes a type name or list of type names
+func (t *Types) UnmarshalJSON(data []byte) error {
+	var name string
+	if err := json.Unmarshal(data, &name); err == nil {
+		*t = Types{name}
+		return nil
+	}
+	var names []string
+	if err := json.Unmarshal(data, &names); err != nil {
+		return err
+	}
+	*t = names
+	return nil
+}
+
+// Parse decodes a JSON Schema document
+func Parse(data []byte) (*Schema, error) {
+	s := &Schema{}
+	if err := json.Unmarshal(data, s); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Load reads a JSON Schema document
+func Load(r io.Reader) (*Schema, error) {
+	s := &Schema{}
+	if err := json.NewDecoder(r).Decode(s); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// LoadFile reads a JSON Schema document from a file
+func LoadFile(name string) (*Schema, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return Load(f)
+}
+
+// Resolve returns the subschema ref points to, with s as the document
+// root. Only references within the document are supported, such as
+// "#", "#/$defs/address" or "#/properties/tags/items".
+func (s *Schema) Resolve(ref string) (*Schema, error) {
+	pointer, ok := strings.CutPrefix(ref, "#")
+	if !ok {
+		return nil, fmt.Errorf("unsupported reference %s", ref)
+	}
+	var tokens []string
+	if pointer != "" {
+		tokens = strings.Split(strings.TrimPrefix(pointer, "/"), "/")
+	}
+	cur := s
+	for i := 0; i < len(tokens); i++ {
+		keyword := tokens[i]
+		if keyword == "items" {
+			cur = cur.Items
+		} else {
+			if i+1 == len(tokens) {
+				return nil, fmt.Errorf("reference %s ends at %s", ref, keyword)
+			}
+			i++
+			// JSON pointer escapes
+			name := strings.ReplaceAll(strings.ReplaceAll(tokens[i], "~1", "/"), "~0", "~")
+			var err error
+			if cur, err = cur.named(keyword, name); err != nil {
+				return nil, fmt.Errorf("reference %s: %w", ref, err)
+			}
+		}
+		if cur == nil {
+			return nil, fmt.Errorf("reference %s not found", ref)
+		}
+	}
+	return cur, nil
+}
+
+// named returns the subschema called name under keyword
+func (s *Schema) named(keyword, name string) (*Schema, error) {
+	switch keyword {
+	case "$defs":
+		return s.Defs[name], nil
+	case "definitions":
+		return s.Definitions[name], nil
+	case "properties":
+		return s.Properties[name], nil
+	case "oneOf", "anyOf", "allOf":
+		list := map[string][]*Schema{"oneOf": s.OneOf, "anyOf": s.AnyOf, "allOf": s.AllOf}[keyword]
+		i, err := strconv.Atoi(name)
+		if err != nil || i < 0 || i >= len(list) {
+			return nil, nil
+		}
+		return list[i], nil
+	}
+	return nil, fmt.Errorf("unsupported keyword %s", keyword)
+}
@@ -0,0 +1,90 @@
+package schema
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLoadFile(t *testing.T) {
+	s, err := LoadFile("testdata/user.schema.json")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if len(s.Type) != 1 || s.Type[0] != "object" {
+		t.Errorf("expected type object, got %v", s.Type)
+	}
+	if len(s.Required) != 7 {
+		t.Errorf("expected 7 required properties, got %v", s.Required)
+	}
+	if nick := s.Properties["nickname"]; len(nick.Type) != 2 || nick.Type[1] != "null" {
+		t.Errorf("nickname: expected a list of types, got %v", nick.Type)
+	}
+	if version := s.Properties["version"]; !version.hasConst || version.Const != float64(2) {
+		t.Errorf("version: expected const 2, got %v", version.Const)
+	}
+
+	if _, err := LoadFile("testdata/missing.json"); err == nil {
+		t.Error("expected an error for a missing file")
+	}
+}
+
+func TestParseBooleanSchemas(t *testing.T) {
+	s, err := Parse([]byte(`{"properties": {"anything": true, "nothing": false}}`))
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if s.Properties["anything"].never || !s.Properties["nothing"].never {
+		t.Errorf("expected true and false schemas, got %+v and %+v", s.Properties["anything"], s.Properties["nothing"])
+	}
+}
+
+func TestResolve(t *testing.T) {
+	s, err := Parse([]byte(`{
+		"$defs": {"a/b": {"type": "integer"}},
+		"definitions": {"old": {"type": "boolean"}},
+		"properties": {"list": {"items": {"type": "string"}}},
+		"oneOf": [{"type": "null"}]
+	}`))
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	tests := map[string]string{
+		"#/$defs/a~1b":            "integer",
+		"#/definitions/old":       "boolean",
+		"#/properties/list/items": "string",
+		"#/oneOf/0":               "null",
+	}
+	for ref, typ := range tests {
+		got, err := s.Resolve(ref)
+		if err != nil {
+			t.Errorf("%s: %s", ref, err)
+			continue
+		}
+		if len(got.Type) != 1 || got.Type[0] != typ {
+			t.Errorf("%s: expected type %s, got %v", ref, typ, got.Type)
+		}
+	}
+
+	if root, err := s.Resolve("#"); err != nil || root != s {
+		t.Errorf("#: expected the root, got %v, %v", root, err)
+	}
+	for _, ref := range []string{"#/$defs/missing", "#/oneOf/3", "#/$defs", "#/not/a", "other.json#/a"} {
+		if _, err := s.Resolve(ref); err == nil || !strings.Contains(err.Error(), "reference") {
+			t.Errorf("%s: expected a reference error, got %v", ref, err)
+		}
+	}
+}
+
+func TestParseExclusiveBooleans(t *testing.T) {
+	s, err := Parse([]byte(`{"minimum": 1, "exclusiveMinimum": true, "maximum": 5, "exclusiveMaximum": false}`))
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if s.Minimum != nil || s.ExclusiveMinimum == nil || *s.ExclusiveMinimum != 1 {
+		t.Errorf("expected an exclusive minimum of 1, got %v and %v", s.Minimum, s.ExclusiveMinimum)
+	}
+	if s.ExclusiveMaximum != nil || s.Maximum == nil || *s.Maximum != 5 {
+		t.Errorf("expected an inclusive maximum of 5, got %v and %v", s.Maximum, s.ExclusiveMaximum)
+	}
+}
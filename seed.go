@@ -0,0 +1,116 @@
+package lorem
+
+import (
+	"context"
+	"database/sql"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// WithSQLConfig sets the dialect, column tag and batch size Seed uses.
+// WriteInserts and WriteCopy, which only write SQL, are given theirs.
+func WithSQLConfig(cfg SQLConfig) Option {
+	return func(g *Generator) {
+		g.sqlConfig = cfg
+	}
+}
+
+// Seed inserts n newly filled values of proto's type, a struct, into table
+func Seed(ctx context.Context, db *sql.DB, table string, proto interface{}, n int, opts ...Option) (interface{}, error) {
+	return NewGenerator(opts...).Seed(ctx, db, table, proto, n)
+}
+
+// Seed inserts n newly filled values of proto's type, a struct, into
+// table, with a prepared statement in a transaction for each batch of
+// rows. It returns the inserted values as a slice of proto's type, so
+// Seed(ctx, db, "users", User{}, 10) returns a []User and
+// Seed(ctx, db, "users", &User{}, 10) a []*User. If a batch fails it is
+// rolled back, and the values of the batches already committed are
+// returned with the error.
+//
+// Columns are as for WriteInserts, and the dialect (for placeholders),
+// column tag and batch size are set with WithSQLConfig.
+func (g *Generator) Seed(ctx context.Context, db *sql.DB, table string, proto interface{}, n int) (interface{}, error) {
+	cfg := g.sqlConfig
+	typ, cols, err := sqlColumns(proto, cfg.Tag)
+	if err != nil {
+		return nil, err
+	}
+	if n < 0 {
+		return nil, errNegativeCount
+	}
+	ptrs := reflect.TypeOf(proto).Kind() == reflect.Ptr
+	elem := typ
+	if ptrs {
+		elem = reflect.PtrTo(typ)
+	}
+	inserted := reflect.MakeSlice(reflect.SliceOf(elem), 0, n)
+
+	names := make([]string, len(cols))
+	params := make([]string, len(cols))
+	for i, col := range cols {
+		names[i] = cfg.Dialect.quoteIdent(col.name)
+		params[i] = cfg.Dialect.placeholder(i + 1)
+	}
+	query := "INSERT INTO " + cfg.Dialect.quoteTable(table) +
+		" (" + strings.Join(names, ", ") + ") VALUES (" + strings.Join(params, ", ") + ")"
+
+	for start := 0; start < n; start += cfg.batch() {
+		values, err := g.seedBatch(ctx, db, query, typ, cols, min(cfg.batch(), n-start))
+		if err != nil {
+			return inserted.Interface(), err
+		}
+		for _, v := range values {
+			if !ptrs {
+				v = v.Elem()
+			}
+			inserted = reflect.Append(inserted, v)
+		}
+	}
+	return inserted.Interface(), nil
+}
+
+// seedBatch inserts n newly filled values of typ in a transaction,
+// returning pointers to them
+func (g *Generator) seedBatch(ctx context.Context, db *sql.DB, query string, typ reflect.Type, cols []sqlColumn, n int) (values []reflect.Value, err error) {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+	stmt, err := tx.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	values = make([]reflect.Value, n)
+	args := make([]interface{}, len(cols))
+	for i := range values {
+		v, row, err := g.sqlRow(typ, cols)
+		if err != nil {
+			return nil, err
+		}
+		for j, arg := range row {
+			args[j] = arg
+		}
+		if _, err := stmt.ExecContext(ctx, args...); err != nil {
+			return nil, err
+		}
+		values[i] = v
+	}
+	return values, tx.Commit()
+}
+
+// placeholder returns the ith (from 1) parameter of a prepared statement
+func (d Dialect) placeholder(i int) string {
+	if d == Postgres {
+		return "$" + strconv.Itoa(i)
+	}
+	return "?"
+}
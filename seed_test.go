@@ -0,0 +1,205 @@
+package lorem
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// fakeDB records what the fake driver is asked to do
+type fakeDB struct {
+	mu        sync.Mutex
+	queries   []string
+	rows      [][]driver.Value
+	pending   [][]driver.Value
+	commits   int
+	rollbacks int
+	// failAt fails the insert of this row (from 1), counting every row tried
+	failAt int
+	tried  int
+}
+
+var errFakeInsert = errors.New("fake insert failed")
+
+// fakeDriver opens the fakeDB named by the data source name
+type fakeDriver struct{}
+
+var (
+	fakesMu  sync.Mutex
+	fakes    = map[string]*fakeDB{}
+	fakeOnce sync.Once
+)
+
+func (fakeDriver) Open(name string) (driver.Conn, error) {
+	fakesMu.Lock()
+	defer fakesMu.Unlock()
+	return fakeConn{fakes[name]}, nil
+}
+
+type fakeConn struct{ db *fakeDB }
+
+func (c fakeConn) Prepare(query string) (driver.Stmt, error) {
+	c.db.mu.Lock()
+	defer c.db.mu.Unlock()
+	c.db.queries = append(c.db.queries, query)
+	return fakeStmt{c.db, strings.Count(query, "$") + strings.Count(query, "?")}, nil
+}
+
+func (c fakeConn) Close() error { return nil }
+
+func (c fakeConn) Begin() (driver.Tx, error) { return fakeTx{c.db}, nil }
+
+type fakeTx struct{ db *fakeDB }
+
+func (tx fakeTx) Commit() error {
+	tx.db.mu.Lock()
+	defer tx.db.mu.Unlock()
+	tx.db.rows = append(tx.db.rows, tx.db.pending...)
+	tx.db.pending = nil
+	tx.db.commits++
+	return nil
+}
+
+func (tx fakeTx) Rollback() error {
+	tx.db.mu.Lock()
+	defer tx.db.mu.Unlock()
+	tx.db.pending = nil
+	tx.db.rollbacks++
+	return nil
+}
+
+type fakeStmt struct {
+	db     *fakeDB
+	inputs int
+}
+
+func (s fakeStmt) Close() error  { return nil }
+func (s fakeStmt) NumInput() int { return s.inputs }
+
+func (s fakeStmt) Exec(args []driver.Value) (driver.Result, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+	s.db.tried++
+	if s.db.tried == s.db.failAt {
+		return nil, errFakeInsert
+	}
+	s.db.pending = append(s.db.pending, args)
+	return driver.RowsAffected(1), nil
+}
+
+func (s fakeStmt) Query([]driver.Value) (driver.Rows, error) {
+	return nil, errors.New("fake driver can't query")
+}
+
+// openFake returns a database using the fake driver, recording to a new fakeDB
+func openFake(t *testing.T) (*sql.DB, *fakeDB) {
+	t.Helper()
+	fakeOnce.Do(func() { sql.Register("lorem-fake", fakeDriver{}) })
+	fake := &fakeDB{}
+	fakesMu.Lock()
+	fakes[t.Name()] = fake
+	fakesMu.Unlock()
+	db, err := sql.Open("lorem-fake", t.Name())
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	t.Cleanup(func() { db.Close() })
+	return db, fake
+}
+
+type SeedUser struct {
+	ID    int64   `db:"-"`
+	Email string  `db:"email" lorem:"email,unique"`
+	Name  string  `db:"name" lorem:"name"`
+	Bio   *string `db:"bio" lorem:"-"`
+	Tags  []string
+}
+
+func TestSeed(t *testing.T) {
+	db, fake := openFake(t)
+	got, err := Seed(context.Background(), db, "users", SeedUser{}, 250)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	users, ok := got.([]SeedUser)
+	if !ok || len(users) != 250 {
+		t.Fatalf("expected 250 users, got %T of %d", got, reflect.ValueOf(got).Len())
+	}
+
+	want := `INSERT INTO "users" ("email", "name", "bio") VALUES ($1, $2, $3)`
+	if len(fake.queries) != 3 || fake.queries[0] != want {
+		t.Errorf("expected a prepared statement per batch of 100, %s, got %q", want, fake.queries)
+	}
+	if fake.commits != 3 || fake.rollbacks != 0 {
+		t.Errorf("expected 3 commits, got %d and %d rollbacks", fake.commits, fake.rollbacks)
+	}
+	if len(fake.rows) != 250 {
+		t.Fatalf("expected 250 rows inserted, got %d", len(fake.rows))
+	}
+	for i, u := range users {
+		row := fake.rows[i]
+		if row[0] != u.Email || row[1] != u.Name || row[2] != nil {
+			t.Errorf("row %d: expected the returned %+v to be inserted, got %v", i, u, row)
+		}
+		if !strings.Contains(u.Email, "@") {
+			t.Errorf("row %d: expected a filled user, got %+v", i, u)
+		}
+	}
+}
+
+func TestSeedPointers(t *testing.T) {
+	db, fake := openFake(t)
+	got, err := Seed(context.Background(), db, "app.users", &SeedUser{}, 3,
+		WithSQLConfig(SQLConfig{Dialect: MySQL, Tag: "db", Batch: 2}))
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	users, ok := got.([]*SeedUser)
+	if !ok || len(users) != 3 || users[2] == nil || users[2].Name == "" {
+		t.Fatalf("expected 3 user pointers, got %#v", got)
+	}
+	want := "INSERT INTO `app`.`users` (`email`, `name`, `bio`) VALUES (?, ?, ?)"
+	if len(fake.queries) != 2 || fake.queries[0] != want || fake.commits != 2 {
+		t.Errorf("expected 2 batches of %s, got %q and %d commits", want, fake.queries, fake.commits)
+	}
+}
+
+func TestSeedRollback(t *testing.T) {
+	db, fake := openFake(t)
+	fake.failAt = 5
+	got, err := Seed(context.Background(), db, "users", SeedUser{}, 10, WithSQLConfig(SQLConfig{Batch: 3}))
+	if !errors.Is(err, errFakeInsert) {
+		t.Fatalf("expected the insert error, got %v", err)
+	}
+	users := got.([]SeedUser)
+	if len(users) != 3 || len(fake.rows) != 3 {
+		t.Errorf("expected the first batch of 3 to be kept, got %d returned and %d inserted", len(users), len(fake.rows))
+	}
+	if fake.commits != 1 || fake.rollbacks != 1 {
+		t.Errorf("expected 1 commit and 1 rollback, got %d and %d", fake.commits, fake.rollbacks)
+	}
+}
+
+func TestSeedErrors(t *testing.T) {
+	db, fake := openFake(t)
+	if _, err := Seed(context.Background(), db, "users", "text", 1); err == nil {
+		t.Error("expected an error for a value that isn't a struct")
+	}
+	if _, err := Seed(context.Background(), db, "users", SeedUser{}, -1); err != errNegativeCount {
+		t.Errorf("expected %v, got %v", errNegativeCount, err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := Seed(ctx, db, "users", SeedUser{}, 1); err == nil {
+		t.Error("expected an error for a cancelled context")
+	}
+	if len(fake.rows) != 0 {
+		t.Errorf("expected nothing inserted, got %v", fake.rows)
+	}
+}
@@ -0,0 +1,342 @@
+// Package server serves lorem content over HTTP, for demos and mock backends.
+//
+//	server.Register("user", User{})
+//	http.ListenAndServe(":8080", server.NewHandler())
+//
+// Every endpoint answers GET requests with JSON, plain text or HTML, chosen
+// by the format query parameter (json, text or html) or the Accept header,
+// and a seed query parameter makes the response the same every time
+// (give NewHandler lorem.WithNow for times to be the same too).
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+
+	lorem "github.com/axiomzen/golorem"
+)
+
+// maxCount is the most values a single request can ask for
+const maxCount = 1000
+
+// maxLength is the most letters, words or sentences
+// a single ranged value can ask for
+const maxLength = 100
+
+var (
+	typesMu sync.RWMutex
+	types   = map[string]reflect.Type{}
+)
+
+// Register makes proto's type available at /fill/<name>.
+// Register panics if proto is not a struct (or struct pointer),
+// or if name is empty, contains a slash or is registered twice.
+func Register(name string, proto interface{}) {
+	if name == "" || strings.Contains(name, "/") {
+		panic(fmt.Sprintf("server: Register invalid name %q", name))
+	}
+	typ := reflect.TypeOf(proto)
+	if typ != nil && typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	if typ == nil || typ.Kind() != reflect.Struct {
+		panic("server: Register " + name + " is not a struct")
+	}
+
+	typesMu.Lock()
+	defer typesMu.Unlock()
+	if _, dup := types[name]; dup {
+		panic("server: Register called twice for " + name)
+	}
+	types[name] = typ
+}
+
+func registered(name string) (reflect.Type, bool) {
+	typesMu.RLock()
+	defer typesMu.RUnlock()
+	typ, ok := types[name]
+	return typ, ok
+}
+
+// Handler serves these endpoints, each of which takes an n parameter to
+// return a list of n values instead of one (the plural forms default to 5).
+// The ranged ones take a min and max of up to 100, both inclusive:
+//
+//	/word, /words             ?min=2&max=10 letters
+//	/sentence, /sentences     ?min=5&max=22 words
+//	/paragraph, /paragraphs   ?min=2&max=10 sentences
+//	/email, /url, /host, /name, /firstname, /lastname, /phone, /uuid
+//	/fill/<name>              a type added with Register
+type Handler struct {
+	opts []lorem.Option
+	mux  *http.ServeMux
+}
+
+// NewHandler returns a Handler whose generators use opts
+func NewHandler(opts ...lorem.Option) *Handler {
+	h := &Handler{opts: opts, mux: http.NewServeMux()}
+
+	ranged := map[string]struct {
+		min, max int
+		fn       func(g *lorem.Generator, min, max int) string
+	}{
+		"word":      {2, 10, (*lorem.Generator).Word},
+		"sentence":  {5, 22, (*lorem.Generator).Sentence},
+		"paragraph": {2, 10, (*lorem.Generator).Paragraph},
+	}
+	for name, r := range ranged {
+		r := r
+		h.handle(name, func(g *lorem.Generator, q url.Values) (func() interface{}, error) {
+			min, err := intParam(q, "min", r.min)
+			if err != nil {
+				return nil, err
+			}
+			max, err := intParam(q, "max", r.max)
+			if err != nil {
+				return nil, err
+			}
+			if min < 1 || max < min || max > maxLength {
+				return nil, fmt.Errorf("min and max must be between 1 and %d with min no more than max, got %d and %d", maxLength, min, max)
+			}
+			// max is inclusive, unlike the generator's
+			return func() interface{} { return r.fn(g, min, max+1) }, nil
+		})
+	}
+
+	simple := map[string]func(g *lorem.Generator) string{
+		"email":     (*lorem.Generator).Email,
+		"url":       (*lorem.Generator).URL,
+		"host":      (*lorem.Generator).Host,
+		"name":      (*lorem.Generator).Name,
+		"firstname": (*lorem.Generator).FirstName,
+		"lastname":  (*lorem.Generator).LastName,
+		"phone":     (*lorem.Generator).Phone,
+		"uuid":      (*lorem.Generator).UUID,
+	}
+	for name, fn := range simple {
+		fn := fn
+		h.handle(name, func(g *lorem.Generator, q url.Values) (func() interface{}, error) {
+			return func() interface{} { return fn(g) }, nil
+		})
+	}
+
+	h.mux.HandleFunc("GET /fill/{name}", h.serveFill)
+	return h
+}
+
+// ServeHTTP implements http.Handler
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.mux.ServeHTTP(w, r)
+}
+
+// values is given the request's generator and query, and returns
+// a function making one value, or an error if the query is bad
+type values func(g *lorem.Generator, q url.Values) (func() interface{}, error)
+
+// handle serves /name with one value, or a list with n, and
+// /names with a list of n values defaulting to 5
+func (h *Handler) handle(name string, fn values) {
+	serve := func(defaultN int) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			q := r.URL.Query()
+			g, err := h.generator(q)
+			if err != nil {
+				h.error(w, r, http.StatusBadRequest, err)
+				return
+			}
+			one, err := fn(g, q)
+			if err != nil {
+				h.error(w, r, http.StatusBadRequest, err)
+				return
+			}
+			n, err := count(q, defaultN)
+			if err != nil {
+				h.error(w, r, http.StatusBadRequest, err)
+				return
+			}
+			if n < 0 {
+				h.write(w, r, one())
+				return
+			}
+			list := make([]interface{}, n)
+			for i := range list {
+				list[i] = one()
+			}
+			h.write(w, r, list)
+		}
+	}
+	h.mux.HandleFunc("GET /"+name, serve(-1))
+	h.mux.HandleFunc("GET /"+name+"s", serve(5))
+}
+
+func (h *Handler) serveFill(w http.ResponseWriter, r *http.Request) {
+	name := r.PathValue("name")
+	typ, ok := registered(name)
+	if !ok {
+		h.error(w, r, http.StatusNotFound, fmt.Errorf("no type %s", name))
+		return
+	}
+	q := r.URL.Query()
+	g, err := h.generator(q)
+	if err != nil {
+		h.error(w, r, http.StatusBadRequest, err)
+		return
+	}
+	n, err := count(q, -1)
+	if err != nil {
+		h.error(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	fill := func() (interface{}, error) {
+		v := reflect.New(typ)
+		if err := g.Fill(v.Interface()); err != nil {
+			return nil, err
+		}
+		return v.Interface(), nil
+	}
+	if n < 0 {
+		v, err := fill()
+		if err != nil {
+			h.error(w, r, http.StatusInternalServerError, err)
+			return
+		}
+		h.write(w, r, v)
+		return
+	}
+	list := make([]interface{}, n)
+	for i := range list {
+		if list[i], err = fill(); err != nil {
+			h.error(w, r, http.StatusInternalServerError, err)
+			return
+		}
+	}
+	h.write(w, r, list)
+}
+
+// generator returns the generator for a request, seeded
+// if the query has a seed
+func (h *Handler) generator(q url.Values) (*lorem.Generator, error) {
+	if !q.Has("seed") {
+		return lorem.NewGenerator(h.opts...), nil
+	}
+	seed, err := strconv.ParseInt(q.Get("seed"), 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("seed must be a number, got %q", q.Get("seed"))
+	}
+	opts := append(append([]lorem.Option{}, h.opts...), lorem.WithSeed(seed))
+	return lorem.NewGenerator(opts...), nil
+}
+
+// intParam returns the query parameter name as an int, or def if it's missing
+func intParam(q url.Values, name string, def int) (int, error) {
+	if !q.Has(name) {
+		return def, nil
+	}
+	n, err := strconv.Atoi(q.Get(name))
+	if err != nil {
+		return 0, fmt.Errorf("%s must be a number, got %q", name, q.Get(name))
+	}
+	return n, nil
+}
+
+// count returns the n query parameter, or def (-1 for a single
+// value rather than a list) if it's missing
+func count(q url.Values, def int) (int, error) {
+	n, err := intParam(q, "n", def)
+	if err != nil {
+		return 0, err
+	}
+	if q.Has("n") && (n < 0 || n > maxCount) {
+		return 0, fmt.Errorf("n must be between 0 and %d, got %d", maxCount, n)
+	}
+	return n, nil
+}
+
+// format returns the response format for r: json, text or html
+func format(r *http.Request) (string, error) {
+	if f := r.URL.Query().Get("format"); f != "" {
+		switch f {
+		case "json", "text", "html":
+			return f, nil
+		}
+		return "", fmt.Errorf("format must be json, text or html, got %q", f)
+	}
+	accept := r.Header.Get("Accept")
+	switch {
+	case strings.Contains(accept, "text/html"):
+		return "html", nil
+	case strings.Contains(accept, "text/plain"):
+		return "text", nil
+	}
+	return "json", nil
+}
+
+// write sends v in the request's format. Strings are written as they
+// are in text and as paragraphs in HTML, a list has one entry per line
+// or paragraph, and anything else is written as JSON.
+func (h *Handler) write(w http.ResponseWriter, r *http.Request, v interface{}) {
+	f, err := format(r)
+	if err != nil {
+		h.error(w, r, http.StatusBadRequest, err)
+		return
+	}
+	if f == "json" {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		json.NewEncoder(w).Encode(v)
+		return
+	}
+
+	list, ok := v.([]interface{})
+	if !ok {
+		list = []interface{}{v}
+	}
+	var b strings.Builder
+	for _, entry := range list {
+		str, isString := entry.(string)
+		if !isString {
+			indent := ""
+			if f == "html" {
+				indent = "  "
+			}
+			data, err := json.MarshalIndent(entry, "", indent)
+			if err != nil {
+				h.error(w, r, http.StatusInternalServerError, err)
+				return
+			}
+			str = string(data)
+		}
+		switch {
+		case f == "text":
+			b.WriteString(str + "\n")
+		case isString:
+			b.WriteString("<p>" + html.EscapeString(str) + "</p>\n")
+		default:
+			b.WriteString("<pre>" + html.EscapeString(str) + "</pre>\n")
+		}
+	}
+	if f == "html" {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	} else {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	}
+	w.Write([]byte(b.String()))
+}
+
+// error sends err with the given status, as JSON if that's the format
+func (h *Handler) error(w http.ResponseWriter, r *http.Request, status int, err error) {
+	if f, _ := format(r); f == "json" {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(status)
+		json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+		return
+	}
+	http.Error(w, err.Error(), status)
+}
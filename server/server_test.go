@@ -0,0 +1,208 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	lorem "github.com/axiomzen/golorem"
+)
+
+type Profile struct {
+	Name    string    `lorem:"name" json:"name"`
+	Email   string    `lorem:"email" json:"email"`
+	Joined  time.Time `json:"joined"`
+	Friends []string  `lorem:"[1,3]firstname" json:"friends"`
+}
+
+func init() {
+	Register("profile", Profile{})
+}
+
+func get(t *testing.T, h http.Handler, target, accept string) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodGet, target, nil)
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestEndpoints(t *testing.T) {
+	h := NewHandler()
+	uuid := regexp.MustCompile(`^"[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}"$`)
+
+	rec := get(t, h, "/uuid", "")
+	if rec.Code != http.StatusOK || rec.Header().Get("Content-Type") != "application/json; charset=utf-8" {
+		t.Errorf("/uuid: expected JSON, got %d %s", rec.Code, rec.Header().Get("Content-Type"))
+	}
+	if body := strings.TrimSpace(rec.Body.String()); !uuid.MatchString(body) {
+		t.Errorf("/uuid: expected a uuid, got %s", body)
+	}
+
+	var words []string
+	rec = get(t, h, "/words?min=3&max=3&n=7", "")
+	if err := json.Unmarshal(rec.Body.Bytes(), &words); err != nil {
+		t.Fatal(err.Error())
+	}
+	if len(words) != 7 {
+		t.Errorf("/words: expected 7 words, got %v", words)
+	}
+	for _, w := range words {
+		if len(w) != 3 {
+			t.Errorf("/words: expected 3 letter words, got %q", w)
+		}
+	}
+
+	// max is inclusive
+	rec = get(t, h, "/words?min=3&max=4&n=200&seed=1", "")
+	if err := json.Unmarshal(rec.Body.Bytes(), &words); err != nil {
+		t.Fatal(err.Error())
+	}
+	lengths := map[int]int{}
+	for _, w := range words {
+		lengths[len(w)]++
+	}
+	if lengths[3] == 0 || lengths[4] == 0 || lengths[3]+lengths[4] != 200 {
+		t.Errorf("/words: expected 3 and 4 letter words, got %v", lengths)
+	}
+
+	var paragraphs []string
+	rec = get(t, h, "/paragraphs", "")
+	if err := json.Unmarshal(rec.Body.Bytes(), &paragraphs); err != nil {
+		t.Fatal(err.Error())
+	}
+	if len(paragraphs) != 5 {
+		t.Errorf("/paragraphs: expected 5 paragraphs by default, got %d", len(paragraphs))
+	}
+
+	for _, path := range []string{"/word", "/sentence", "/paragraph", "/email", "/url", "/host", "/name", "/firstname", "/lastname", "/phone"} {
+		var str string
+		rec := get(t, h, path, "")
+		if err := json.Unmarshal(rec.Body.Bytes(), &str); err != nil || str == "" {
+			t.Errorf("%s: expected a string, got %d %s", path, rec.Code, rec.Body)
+		}
+	}
+}
+
+func TestFill(t *testing.T) {
+	h := NewHandler()
+
+	var p Profile
+	rec := get(t, h, "/fill/profile", "")
+	if err := json.Unmarshal(rec.Body.Bytes(), &p); err != nil {
+		t.Fatal(err.Error())
+	}
+	if p.Name == "" || !strings.Contains(p.Email, "@") || len(p.Friends) == 0 {
+		t.Errorf("expected a filled profile, got %+v", p)
+	}
+
+	var ps []Profile
+	rec = get(t, h, "/fill/profile?n=20", "")
+	if err := json.Unmarshal(rec.Body.Bytes(), &ps); err != nil {
+		t.Fatal(err.Error())
+	}
+	if len(ps) != 20 {
+		t.Errorf("expected 20 profiles, got %d", len(ps))
+	}
+
+	if rec := get(t, h, "/fill/missing", ""); rec.Code != http.StatusNotFound {
+		t.Errorf("expected 404 for an unregistered type, got %d", rec.Code)
+	}
+}
+
+func TestFormats(t *testing.T) {
+	h := NewHandler()
+
+	rec := get(t, h, "/sentences?n=3&format=text", "")
+	if rec.Header().Get("Content-Type") != "text/plain; charset=utf-8" {
+		t.Errorf("expected plain text, got %s", rec.Header().Get("Content-Type"))
+	}
+	if lines := strings.Split(strings.TrimSpace(rec.Body.String()), "\n"); len(lines) != 3 {
+		t.Errorf("expected 3 lines, got %q", rec.Body)
+	}
+
+	rec = get(t, h, "/paragraphs?n=2", "text/html,application/xhtml+xml")
+	if rec.Header().Get("Content-Type") != "text/html; charset=utf-8" {
+		t.Errorf("expected HTML from the Accept header, got %s", rec.Header().Get("Content-Type"))
+	}
+	if n := strings.Count(rec.Body.String(), "<p>"); n != 2 {
+		t.Errorf("expected 2 paragraphs, got %q", rec.Body)
+	}
+
+	rec = get(t, h, "/fill/profile?format=html", "")
+	if !strings.HasPrefix(rec.Body.String(), "<pre>{\n") || !strings.Contains(rec.Body.String(), "&#34;email&#34;") {
+		t.Errorf("expected escaped JSON in a pre block, got %q", rec.Body)
+	}
+
+	rec = get(t, h, "/email", "text/plain")
+	if body := rec.Body.String(); !strings.Contains(body, "@") || strings.Contains(body, `"`) {
+		t.Errorf("expected a plain email, got %q", body)
+	}
+}
+
+func TestSeed(t *testing.T) {
+	h := NewHandler(lorem.WithNow(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)))
+	for _, path := range []string{"/paragraphs?seed=42", "/fill/profile?n=3&seed=42", "/uuid?seed=42"} {
+		a := get(t, h, path, "").Body.String()
+		b := get(t, h, path, "").Body.String()
+		if a != b {
+			t.Errorf("%s: expected the same response for the same seed, got %s and %s", path, a, b)
+		}
+		other := get(t, h, strings.Replace(path, "seed=42", "seed=43", 1), "").Body.String()
+		if a == other {
+			t.Errorf("%s: expected a different response for a different seed", path)
+		}
+	}
+}
+
+func TestBadRequests(t *testing.T) {
+	h := NewHandler()
+	for _, path := range []string{
+		"/words?n=lots",
+		"/words?n=-1",
+		"/words?n=100000",
+		"/word?min=5&max=2",
+		"/sentences?max=2000000000",
+		"/paragraphs?min=101&max=101",
+		"/word?min=0",
+		"/sentence?max=x",
+		"/uuid?seed=abc",
+		"/email?format=xml",
+		"/fill/profile?n=-3",
+	} {
+		rec := get(t, h, path, "")
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected 400, got %d", path, rec.Code)
+		}
+		var body map[string]string
+		if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil && !strings.Contains(path, "format") {
+			t.Errorf("%s: expected a JSON error, got %q", path, rec.Body)
+		} else if err == nil && body["error"] == "" {
+			t.Errorf("%s: expected an error message, got %q", path, rec.Body)
+		}
+	}
+
+	if rec := get(t, h, "/nothing", ""); rec.Code != http.StatusNotFound {
+		t.Errorf("expected 404 for an unknown path, got %d", rec.Code)
+	}
+}
+
+func TestRegisterPanics(t *testing.T) {
+	for name, proto := range map[string]interface{}{"": Profile{}, "a/b": Profile{}, "number": 5, "profile": Profile{}} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%q: expected Register to panic", name)
+				}
+			}()
+			Register(name, proto)
+		}()
+	}
+}
@@ -0,0 +1,65 @@
+package lorem
+
+import (
+	"reflect"
+	"strings"
+)
+
+// WithSparse leaves each optional field out (at its zero value) with
+// probability rate, so every value has a different mix of fields present.
+// Optional fields are pointers, slices, maps, interfaces, database/sql Null
+// types and fields tagged json omitempty, unless another field's tag refers
+// to them or, with WithValidate, they're tagged validate:"required". With
+// WithSeed the same seed always leaves out the same fields, so looping over
+// seeds enumerates reproducible combinations.
+func WithSparse(rate float64) Option {
+	return func(g *Generator) {
+		g.sparseRate = rate
+	}
+}
+
+// absent decides whether to leave out an optional field
+func (g *Generator) absent() bool {
+	return g.sparseRate > 0 && g.rand.Float64() < g.sparseRate
+}
+
+// optionalFields reports which fields of typ can be left out in sparse
+// fills, which excludes any field referred to in refs and any field
+// the generator's validate rules require
+func (g *Generator) optionalFields(typ reflect.Type, refs []*fieldRefs) []bool {
+	optional := make([]bool, typ.NumField())
+	for i := range optional {
+		sf := typ.Field(i)
+		r := g.validateRules(sf)
+		optional[i] = isOptional(sf) && (r == nil || !r.has("required"))
+	}
+	for _, r := range refs {
+		if r == nil {
+			continue
+		}
+		for _, name := range r.names() {
+			if sf, ok := typ.FieldByName(name); ok && len(sf.Index) == 1 {
+				optional[sf.Index[0]] = false
+			}
+		}
+	}
+	return optional
+}
+
+// isOptional reports whether the field looks like it can be left out
+func isOptional(sf reflect.StructField) bool {
+	switch sf.Type.Kind() {
+	case reflect.Ptr, reflect.Slice, reflect.Map, reflect.Interface:
+		return true
+	}
+	if isNullType(sf.Type) {
+		return true
+	}
+	_, opts, _ := strings.Cut(sf.Tag.Get("json"), ",")
+	for _, opt := range strings.Split(opts, ",") {
+		if opt == "omitempty" {
+			return true
+		}
+	}
+	return false
+}
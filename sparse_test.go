@@ -0,0 +1,105 @@
+package lorem
+
+import (
+	"database/sql"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type SparseStruct struct {
+	ID       int
+	Name     string
+	Nickname *string
+	Tags     []string
+	Extra    map[string]string `lorem:"[1,3]"`
+	Note     string            `json:"note,omitempty"`
+	Deleted  sql.NullTime
+	Source   *string `lorem:"word"`
+	Derived  string  `lorem:"from=Source"`
+}
+
+func TestWithSparse(t *testing.T) {
+	present := map[string]int{}
+	for i := 0; i < 200; i++ {
+		var ss SparseStruct
+		if err := Fill(&ss, WithSparse(0.5)); err != nil {
+			t.Fatal(err.Error())
+		}
+
+		if ss.ID == 0 || ss.Name == "" {
+			t.Errorf("expected required fields to always be filled, got %+v", ss)
+		}
+		if ss.Source == nil || ss.Derived != *ss.Source {
+			t.Errorf("Source: expected referenced field to always be filled, got %+v", ss)
+		}
+		v := reflect.ValueOf(ss)
+		for _, name := range []string{"Nickname", "Tags", "Extra", "Note", "Deleted"} {
+			if !v.FieldByName(name).IsZero() {
+				present[name]++
+			}
+		}
+	}
+
+	for _, name := range []string{"Nickname", "Tags", "Extra", "Note", "Deleted"} {
+		if present[name] < 50 || present[name] > 150 {
+			t.Errorf("%s: expected to be present about half of 200 times, got %d", name, present[name])
+		}
+	}
+}
+
+func TestWithSparseSeeds(t *testing.T) {
+	now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	combinations := map[string]bool{}
+	for seed := int64(0); seed < 50; seed++ {
+		var a, b SparseStruct
+		if err := Fill(&a, WithSparse(0.5), WithSeed(seed), WithNow(now)); err != nil {
+			t.Fatal(err.Error())
+		}
+		if err := Fill(&b, WithSparse(0.5), WithSeed(seed), WithNow(now)); err != nil {
+			t.Fatal(err.Error())
+		}
+		if !reflect.DeepEqual(a, b) {
+			t.Errorf("Expected seed %d to give the same values, got %+v and %+v", seed, a, b)
+		}
+
+		combination := ""
+		v := reflect.ValueOf(a)
+		for _, name := range []string{"Nickname", "Tags", "Extra", "Note", "Deleted"} {
+			if v.FieldByName(name).IsZero() {
+				combination += "0"
+			} else {
+				combination += "1"
+			}
+		}
+		combinations[combination] = true
+	}
+	if len(combinations) < 10 {
+		t.Errorf("Expected many combinations over 50 seeds, got %d", len(combinations))
+	}
+}
+
+func TestWithSparseValidate(t *testing.T) {
+	type Account struct {
+		Owner   *string  `validate:"required"`
+		Roles   []string `validate:"required,min=1,dive,oneof=admin user"`
+		Backup  *string  `validate:"omitempty,email"`
+		Aliases []string `lorem:"[1,3]" validate:"required"`
+	}
+	missing := 0
+	for i := 0; i < 200; i++ {
+		var a Account
+		if err := Fill(&a, WithValidate(), WithSparse(0.5)); err != nil {
+			t.Fatal(err.Error())
+		}
+		if a.Owner == nil || len(a.Roles) == 0 || len(a.Aliases) == 0 {
+			t.Fatalf("expected required fields to be filled, got %+v", a)
+		}
+		if a.Backup == nil {
+			missing++
+		}
+	}
+	if missing == 0 || missing == 200 {
+		t.Errorf("Backup: expected the optional field to be left out sometimes, got %d of 200", missing)
+	}
+}
@@ -0,0 +1,262 @@
+package lorem
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Spec is a parsed lorem tag, as given to a Filler.
+// `lorem:"word,2,8"` has Kind "word" and Args ["2", "8"], and
+// a literal such as `lorem:",hello"` has an empty Kind and Args ["hello"].
+type Spec struct {
+	Tag  string
+	Kind string
+	Args []string
+}
+
+// ParseSpec parses a lorem tag
+func ParseSpec(tag string) Spec {
+	spec := Spec{Tag: tag}
+	if tag == "" {
+		return spec
+	}
+	args := strings.Split(tag, ",")
+	spec.Kind = args[0]
+	spec.Args = args[1:]
+	return spec
+}
+
+// Arg returns the i'th argument, or "" if there is none
+func (s Spec) Arg(i int) string {
+	if i < 0 || i >= len(s.Args) {
+		return ""
+	}
+	return s.Args[i]
+}
+
+// Int returns the i'th argument as an int, or def if
+// there is none or it isn't a number
+func (s Spec) Int(i, def int) int {
+	n, err := strconv.Atoi(s.Arg(i))
+	if err != nil {
+		return def
+	}
+	return n
+}
+
+var (
+	registeredSpecsMu sync.RWMutex
+	registeredSpecs   = map[reflect.Type]map[string]string{}
+)
+
+// RegisterSpec registers lorem tags for the fields of proto's type, for
+// types you cannot add tags to (generated code for example). specs maps
+// field names to tags, exactly as they would be written in `lorem:"..."`.
+// Registered specs are used wherever that type is filled, and take
+// precedence over the struct tags.
+// RegisterSpec panics if proto is not a struct (or struct pointer),
+// or if a field does not exist.
+func RegisterSpec(proto interface{}, specs map[string]string) {
+	typ := reflect.TypeOf(proto)
+	if typ != nil && typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	if typ == nil || typ.Kind() != reflect.Struct {
+		panic("lorem: RegisterSpec " + errInvalidSpecification.Error())
+	}
+
+	registeredSpecsMu.Lock()
+	defer registeredSpecsMu.Unlock()
+	fields, ok := registeredSpecs[typ]
+	if !ok {
+		fields = map[string]string{}
+		registeredSpecs[typ] = fields
+	}
+	for name, spec := range specs {
+		if _, ok := typ.FieldByName(name); !ok {
+			panic(fmt.Sprintf("lorem: RegisterSpec %s has no field %s", typ, name))
+		}
+		fields[name] = spec
+	}
+}
+
+func registeredSpec(typ reflect.Type, name string) (string, bool) {
+	registeredSpecsMu.RLock()
+	defer registeredSpecsMu.RUnlock()
+	spec, ok := registeredSpecs[typ][name]
+	return spec, ok
+}
+
+// WithSpecs supplies lorem tags by field path, for fields you cannot add
+// tags to. Paths are dotted field names from the filled struct, for example
+// "Email" or "Address.City", or the names given by WithFieldNames;
+// slice elements share the path of their slice.
+// These take precedence over registered specs and struct tags.
+func WithSpecs(specs map[string]string) Option {
+	return func(g *Generator) {
+		if g.specs == nil {
+			g.specs = map[string]string{}
+		}
+		for path, spec := range specs {
+			g.specs[path] = spec
+		}
+	}
+}
+
+// LoadSpecs reads a JSON object of field paths to lorem tags,
+// suitable for WithSpecs
+func LoadSpecs(r io.Reader) (map[string]string, error) {
+	specs := map[string]string{}
+	if err := json.NewDecoder(r).Decode(&specs); err != nil {
+		return nil, err
+	}
+	return specs, nil
+}
+
+// LoadYAMLSpecs reads a YAML mapping of field paths to lorem tags,
+// suitable for WithSpecs. Only a flat mapping of one key: value per line
+// is understood, with comments and quoted keys and values; values that
+// start with a YAML indicator, such as the comma of ",Calgary", must be
+// quoted.
+func LoadYAMLSpecs(r io.Reader) (map[string]string, error) {
+	specs := map[string]string{}
+	scanner := bufio.NewScanner(r)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimRight(scanner.Text(), " \t\r")
+		if trimmed := strings.TrimSpace(line); trimmed == "" || trimmed[0] == '#' || trimmed == "---" {
+			continue
+		}
+		if line[0] == ' ' || line[0] == '\t' {
+			return nil, fmt.Errorf("line %d: specs must be a flat mapping of paths to tags", n)
+		}
+		key, rest, err := yamlScalar(line, true)
+		if err == nil && !strings.HasPrefix(rest, ":") {
+			err = errors.New("expected path: tag")
+		}
+		var value string
+		if err == nil {
+			if value, rest, err = yamlScalar(strings.TrimSpace(rest[1:]), false); err == nil && rest != "" && rest[0] != '#' {
+				err = fmt.Errorf("unexpected %q after the tag", rest)
+			}
+		}
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %s", n, err)
+		}
+		specs[key] = value
+	}
+	return specs, scanner.Err()
+}
+
+// yamlScalar reads the quoted or plain scalar at the start of s, a key
+// ending at ": " or a value ending at " #", returning it and the rest of s
+func yamlScalar(s string, key bool) (string, string, error) {
+	switch {
+	case s == "":
+		return "", "", nil
+	case s[0] == '"':
+		for i := 1; i < len(s); i++ {
+			switch s[i] {
+			case '\\':
+				i++
+			case '"':
+				v, err := strconv.Unquote(s[:i+1])
+				return v, strings.TrimSpace(s[i+1:]), err
+			}
+		}
+		return "", "", errors.New("unterminated quoted string")
+	case s[0] == '\'':
+		var b strings.Builder
+		for i := 1; i < len(s); i++ {
+			if s[i] != '\'' {
+				b.WriteByte(s[i])
+			} else if i+1 < len(s) && s[i+1] == '\'' {
+				b.WriteByte('\'')
+				i++
+			} else {
+				return b.String(), strings.TrimSpace(s[i+1:]), nil
+			}
+		}
+		return "", "", errors.New("unterminated quoted string")
+	case strings.ContainsRune(",[]{}&*!|>%@`", rune(s[0])):
+		return "", "", fmt.Errorf("%q must be quoted", s)
+	}
+	end := " #"
+	if key {
+		end = ":"
+	}
+	i := strings.Index(s, end)
+	for key && i >= 0 && i+1 < len(s) && s[i+1] != ' ' {
+		// a colon not followed by a space is part of the key
+		j := strings.Index(s[i+1:], end)
+		if j < 0 {
+			i = -1
+			break
+		}
+		i += 1 + j
+	}
+	if i < 0 {
+		return strings.TrimSpace(s), "", nil
+	}
+	return strings.TrimSpace(s[:i]), strings.TrimSpace(s[i:]), nil
+}
+
+// LoadSpecFile reads a file of field paths to lorem tags, suitable for
+// WithSpecs: YAML (see LoadYAMLSpecs) if it is named .yaml or .yml,
+// and JSON otherwise
+func LoadSpecFile(name string) (map[string]string, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".yaml", ".yml":
+		return LoadYAMLSpecs(f)
+	}
+	return LoadSpecs(f)
+}
+
+// fieldTag returns the lorem tag for the field sf of the struct type parent
+// at path (or alias). Specs given to the generator win, then registered
+// specs, then the struct tag and finally name inference.
+func (g *Generator) fieldTag(parent reflect.Type, path, alias string, sf reflect.StructField) string {
+	if tag, ok := g.explicitTag(parent, path, alias, sf); ok {
+		return tag
+	}
+	return g.inferTag(sf)
+}
+
+// explicitTag returns the lorem tag given for the field at path (or alias,
+// the same path using the WithFieldNames names) by the generator's specs,
+// registered specs or the struct tag, and whether there was one
+func (g *Generator) explicitTag(parent reflect.Type, path, alias string, sf reflect.StructField) (string, bool) {
+	if spec, ok := g.specs[path]; ok {
+		return spec, true
+	}
+	if spec, ok := g.specs[alias]; ok {
+		return spec, true
+	}
+	if spec, ok := registeredSpec(parent, sf.Name); ok {
+		return spec, true
+	}
+	if tag := sf.Tag.Get("lorem"); tag != "" {
+		return tag, true
+	}
+	return "", false
+}
+
+func joinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
@@ -0,0 +1,198 @@
+package lorem
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// pretend these come from generated code and can't have tags
+type GeneratedAddress struct {
+	Street string
+	City   string
+}
+
+type GeneratedUser struct {
+	Email     string
+	Nickname  string
+	Ignored   string
+	Address   GeneratedAddress
+	Addresses []*GeneratedAddress
+}
+
+type RegisteredAddress struct {
+	Street string
+	City   string `lorem:"word,3,3"`
+}
+
+type StructWithRegistered struct {
+	Address  RegisteredAddress
+	Pointers []*RegisteredAddress
+}
+
+func TestWithSpecs(t *testing.T) {
+	var gu GeneratedUser
+	err := Fill(&gu, WithSpecs(map[string]string{
+		"Email":          "email",
+		"Nickname":       ",nick",
+		"Ignored":        "-",
+		"Address.City":   ",Vancouver",
+		"Addresses":      "[2,3]",
+		"Addresses.City": ",Toronto",
+	}))
+	if err != nil {
+		t.Error(err.Error())
+	}
+
+	if !strings.Contains(gu.Email, "@") {
+		t.Errorf("Email: expected Email to contain '@', got %s", gu.Email)
+	}
+	if gu.Nickname != "nick" {
+		t.Errorf("Nickname: expected %s, got %s", "nick", gu.Nickname)
+	}
+	if gu.Ignored != "" {
+		t.Errorf("Ignored: expected empty string, got %s", gu.Ignored)
+	}
+	if gu.Address.City != "Vancouver" {
+		t.Errorf("Address.City: expected %s, got %s", "Vancouver", gu.Address.City)
+	}
+	if gu.Address.Street == "" {
+		t.Errorf("Address.Street: expected string not empty, got %s", gu.Address.Street)
+	}
+	if len(gu.Addresses) < 2 || len(gu.Addresses) > 3 {
+		t.Errorf("Addresses: expected 2 <= len(Addresses) <= 3, got %d", len(gu.Addresses))
+	}
+	for _, a := range gu.Addresses {
+		if a.City != "Toronto" {
+			t.Errorf("Addresses.City: expected %s, got %s", "Toronto", a.City)
+		}
+	}
+}
+
+func TestRegisterSpec(t *testing.T) {
+	RegisterSpec(RegisteredAddress{}, map[string]string{
+		"Street": ",Main St",
+		"City":   ",Ottawa",
+	})
+
+	var ss StructWithRegistered
+	if err := Fill(&ss); err != nil {
+		t.Error(err.Error())
+	}
+
+	if ss.Address.Street != "Main St" {
+		t.Errorf("Address.Street: expected %s, got %s", "Main St", ss.Address.Street)
+	}
+	if ss.Address.City != "Ottawa" {
+		t.Errorf("Address.City: expected registered spec to win, got %s", ss.Address.City)
+	}
+	for _, a := range ss.Pointers {
+		if a.Street != "Main St" {
+			t.Errorf("Pointers.Street: expected %s, got %s", "Main St", a.Street)
+		}
+	}
+
+	// path specs win over registered ones
+	if err := Fill(&ss, WithSpecs(map[string]string{"Address.City": ",Halifax"})); err != nil {
+		t.Error(err.Error())
+	}
+	if ss.Address.City != "Halifax" {
+		t.Errorf("Address.City: expected %s, got %s", "Halifax", ss.Address.City)
+	}
+}
+
+func TestRegisterSpecPanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected RegisterSpec to panic on unknown field")
+		}
+	}()
+	RegisterSpec(&RegisteredAddress{}, map[string]string{"Nope": "word"})
+}
+
+func TestLoadSpecFile(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "specs.json")
+	if err := os.WriteFile(name, []byte(`{"Email": "email", "Address.City": ",Calgary"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	specs, err := LoadSpecFile(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var gu GeneratedUser
+	if err := Fill(&gu, WithSpecs(specs)); err != nil {
+		t.Error(err.Error())
+	}
+	if !strings.Contains(gu.Email, "@") {
+		t.Errorf("Email: expected Email to contain '@', got %s", gu.Email)
+	}
+	if gu.Address.City != "Calgary" {
+		t.Errorf("Address.City: expected %s, got %s", "Calgary", gu.Address.City)
+	}
+
+	if _, err := LoadSpecs(strings.NewReader(`["not", "an", "object"]`)); err == nil {
+		t.Error("Expected error, got nil")
+	}
+}
+
+func TestLoadSpecFileYAML(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "specs.yaml")
+	data := `# specs for GeneratedUser
+Email: email
+"Address.City": ',Calgary'  # quoted, as it starts with a comma
+Address.Street: "word,3,3" # a comment
+`
+	if err := os.WriteFile(name, []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	specs, err := LoadSpecFile(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string]string{"Email": "email", "Address.City": ",Calgary", "Address.Street": "word,3,3"}
+	if !reflect.DeepEqual(specs, expected) {
+		t.Errorf("expected %v, got %v", expected, specs)
+	}
+
+	for _, bad := range []string{
+		"Address:\n  City: word\n",
+		"Address.City: ,Calgary\n",
+		"Email\n",
+		"Email: \"email\n",
+		"- Email\n",
+	} {
+		if _, err := LoadYAMLSpecs(strings.NewReader(bad)); err == nil {
+			t.Errorf("expected an error for %q, got nil", bad)
+		}
+	}
+}
+
+func TestParseSpec(t *testing.T) {
+	spec := ParseSpec("word,2,x")
+	if spec.Kind != "word" {
+		t.Errorf("Expected %s, got %s", "word", spec.Kind)
+	}
+	if spec.Int(0, 0) != 2 {
+		t.Errorf("Expected %d, got %d", 2, spec.Int(0, 0))
+	}
+	if spec.Int(1, 10) != 10 {
+		t.Errorf("Expected %d, got %d", 10, spec.Int(1, 10))
+	}
+	if spec.Arg(5) != "" {
+		t.Errorf("Expected empty string, got %s", spec.Arg(5))
+	}
+
+	literal := ParseSpec(",hello")
+	if literal.Kind != "" || literal.Arg(0) != "hello" {
+		t.Errorf("Expected literal hello, got %+v", literal)
+	}
+
+	if empty := ParseSpec(""); empty.Kind != "" || len(empty.Args) != 0 {
+		t.Errorf("Expected empty spec, got %+v", empty)
+	}
+}
@@ -0,0 +1,81 @@
+package lorem
+
+import (
+	"database/sql"
+	"reflect"
+	"strings"
+)
+
+var scannerType = reflect.TypeOf((*sql.Scanner)(nil)).Elem()
+
+// WithNullRate sets the probability (0 to 1) that database/sql Null types
+// (sql.NullString, sql.NullInt64, sql.Null[T] ...) and other sql.Scanner
+// types are left null. The default is 0, so they are always valid.
+// Scanners that return an error for a nil value always get a value.
+func WithNullRate(rate float64) Option {
+	return func(g *Generator) {
+		g.nullRate = rate
+	}
+}
+
+// isNullType reports whether typ is one of the database/sql Null types,
+// which all have a value field followed by Valid
+func isNullType(typ reflect.Type) bool {
+	return typ.Kind() == reflect.Struct &&
+		typ.PkgPath() == "database/sql" &&
+		strings.HasPrefix(typ.Name(), "Null") &&
+		typ.NumField() == 2 &&
+		typ.Field(1).Name == "Valid"
+}
+
+// fillSQL fills database/sql Null types using the tag for their value,
+// and other sql.Scanner types by scanning a generated value into them.
+// It returns false if field is neither.
+func (g *Generator) fillSQL(path, alias, tag string, field reflect.Value) (bool, error) {
+	typ := field.Type()
+	if isNullType(typ) {
+		field.Set(reflect.Zero(typ))
+		if g.null() {
+			return true, nil
+		}
+		field.Field(1).SetBool(true)
+		return true, g.fillRec(path, alias, tag, field.Field(0))
+	}
+
+	if !field.CanAddr() || !reflect.PtrTo(typ).Implements(scannerType) {
+		return false, nil
+	}
+	scanner := field.Addr().Interface().(sql.Scanner)
+	// scanners that can't be null get a value instead
+	if g.null() && scanner.Scan(nil) == nil {
+		return true, nil
+	}
+
+	// we don't know what the scanner wants, so try the
+	// tag's string first, then the other driver.Value types
+	str, err := g.stringFromTag(tag)
+	if err != nil {
+		return true, err
+	}
+	if err = scanner.Scan(str); err == nil {
+		return true, nil
+	}
+	candidates := []interface{}{
+		g.rand.Int63(),
+		g.rand.Float64(),
+		g.rand.Int()%2 == 0,
+		g.Time(),
+		[]byte(str),
+	}
+	for _, v := range candidates {
+		if scanner.Scan(v) == nil {
+			return true, nil
+		}
+	}
+	// report why the tag's value didn't work
+	return true, err
+}
+
+func (g *Generator) null() bool {
+	return g.nullRate > 0 && g.rand.Float64() < g.nullRate
+}
@@ -0,0 +1,133 @@
+package lorem
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// Cents only scans integers, like a custom money column
+type Cents struct {
+	value int64
+	valid bool
+}
+
+func (c *Cents) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		c.value, c.valid = 0, false
+	case int64:
+		c.value, c.valid = v, true
+	default:
+		return fmt.Errorf("cannot scan %T into Cents", src)
+	}
+	return nil
+}
+
+// Code scans strings
+type Code string
+
+func (c *Code) Scan(src interface{}) error {
+	s, ok := src.(string)
+	if !ok {
+		return fmt.Errorf("cannot scan %T into Code", src)
+	}
+	*c = Code(strings.ToUpper(s))
+	return nil
+}
+
+type StructWithSQLTypes struct {
+	String        sql.NullString `lorem:"email"`
+	Int64         sql.NullInt64
+	Int32         sql.NullInt32
+	Float64       sql.NullFloat64
+	Bool          sql.NullBool `lorem:"true"`
+	Time          sql.NullTime
+	Generic       sql.Null[string] `lorem:",generic"`
+	StringPointer *sql.NullString  `lorem:",pointer"`
+	Cents         Cents
+	Code          Code `lorem:"word,4,4"`
+}
+
+func TestSQLTypes(t *testing.T) {
+	var ss StructWithSQLTypes
+	if err := Fill(&ss); err != nil {
+		t.Error(err.Error())
+	}
+
+	if !ss.String.Valid || !strings.Contains(ss.String.String, "@") {
+		t.Errorf("String: expected valid email, got %+v", ss.String)
+	}
+	if !ss.Int64.Valid || !ss.Int32.Valid || !ss.Float64.Valid || !ss.Time.Valid {
+		t.Errorf("expected Int64, Int32, Float64 and Time to be valid, got %+v", ss)
+	}
+	if ss.Time.Time.IsZero() {
+		t.Errorf("Time: expected time to be set")
+	}
+	if !ss.Bool.Valid || !ss.Bool.Bool {
+		t.Errorf("Bool: expected valid true, got %+v", ss.Bool)
+	}
+	if !ss.Generic.Valid || ss.Generic.V != "generic" {
+		t.Errorf("Generic: expected valid %s, got %+v", "generic", ss.Generic)
+	}
+	if ss.StringPointer == nil || ss.StringPointer.String != "pointer" {
+		t.Errorf("StringPointer: expected valid %s, got %+v", "pointer", ss.StringPointer)
+	}
+	if !ss.Cents.valid {
+		t.Errorf("Cents: expected an int64 to be scanned, got %+v", ss.Cents)
+	}
+	if len(ss.Code) != 4 || strings.ToUpper(string(ss.Code)) != string(ss.Code) {
+		t.Errorf("Code: expected 4 upper case letters, got %s", ss.Code)
+	}
+}
+
+func TestSQLNullRate(t *testing.T) {
+	var never, always StructWithSQLTypes
+	if err := Fill(&always, WithNullRate(1)); err != nil {
+		t.Error(err.Error())
+	}
+	if always.String.Valid || always.String.String != "" {
+		t.Errorf("String: expected null, got %+v", always.String)
+	}
+	if always.Generic.Valid || always.Cents.valid {
+		t.Errorf("expected Generic and Cents to be null, got %+v", always)
+	}
+
+	if err := Fill(&never, WithNullRate(0)); err != nil {
+		t.Error(err.Error())
+	}
+	if !never.String.Valid {
+		t.Errorf("String: expected valid, got %+v", never.String)
+	}
+
+	g := NewGenerator(WithNullRate(0.5), WithSeed(3))
+	valid := 0
+	for i := 0; i < 100; i++ {
+		var ns struct{ S sql.NullString }
+		if err := g.Fill(&ns); err != nil {
+			t.Error(err.Error())
+		}
+		if ns.S.Valid {
+			valid++
+		}
+	}
+	if valid < 25 || valid > 75 {
+		t.Errorf("Expected about half of 100 to be valid, got %d", valid)
+	}
+}
+
+type Unscannable struct{}
+
+func (u *Unscannable) Scan(src interface{}) error {
+	return fmt.Errorf("never scans")
+}
+
+func TestSQLScannerError(t *testing.T) {
+	var ss struct {
+		U Unscannable
+	}
+	if err := Fill(&ss); err == nil || !strings.Contains(err.Error(), "never scans") {
+		t.Errorf("Expected scan error, got %v", err)
+	}
+}
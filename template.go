@@ -0,0 +1,127 @@
+package lorem
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"text/template"
+)
+
+// templateFuncs returns the functions available in template tags, which
+// are the built in generators plus every registered kind, so
+// `lorem:"template,{{sku 8}}"` calls the "sku" kind with []string{"8"}.
+// Kinds whose names aren't identifiers are called with kind instead.
+func (g *Generator) templateFuncs() template.FuncMap {
+	funcs := template.FuncMap{}
+
+	kindsMu.RLock()
+	for name, fn := range kinds {
+		// templates only call functions named like Go identifiers,
+		// other kinds are called with kind, as in {{kind "tenant-id" 8}}
+		if !identifierRegex.MatchString(name) {
+			continue
+		}
+		funcs[name] = g.kindFunc(fn)
+	}
+	kindsMu.RUnlock()
+
+	builtins := template.FuncMap{
+		"word":         g.Word,
+		"sentence":     g.Sentence,
+		"paragraph":    g.Paragraph,
+		"url":          g.URL,
+		"host":         g.Host,
+		"email":        g.Email,
+		"uuid":         g.UUID,
+		"firstname":    g.FirstName,
+		"lastname":     g.LastName,
+		"name":         g.Name,
+		"phone":        g.Phone,
+		"time":         g.Time,
+		"readablepath": ReadablePath,
+		"intRange":     g.IntRange,
+		"digits":       g.digits,
+		"letters":      g.letters,
+		"oneof":        g.oneOf,
+		"year":         func() int { return g.now().Year() },
+		"upper":        strings.ToUpper,
+		"lower":        strings.ToLower,
+		"kind": func(name string, args ...interface{}) (interface{}, error) {
+			fn, _, ok := customKind(name)
+			if !ok {
+				return nil, fmt.Errorf("no kind %q", name)
+			}
+			return g.kindFunc(fn)(args...)
+		},
+	}
+	for name, fn := range builtins {
+		funcs[name] = fn
+	}
+	return funcs
+}
+
+// identifierRegex matches the names template functions can have
+var identifierRegex = regexp.MustCompile(`^[\pL_][\pL\p{Nd}_]*$`)
+
+// kindFunc returns fn as a template function, passing its
+// arguments to fn as strings
+func (g *Generator) kindFunc(fn KindFunc) func(args ...interface{}) (interface{}, error) {
+	return func(args ...interface{}) (interface{}, error) {
+		strs := make([]string, len(args))
+		for i, arg := range args {
+			strs[i] = fmt.Sprint(arg)
+		}
+		return fn(g, strs)
+	}
+}
+
+// execTemplate runs a template tag, parsing it the first time it's used
+func (g *Generator) execTemplate(text string) (string, error) {
+	g.templatesMu.Lock()
+	tmpl, ok := g.templates[text]
+	if !ok {
+		var err error
+		tmpl, err = template.New("lorem").Funcs(g.templateFuncs()).Parse(text)
+		if err != nil {
+			g.templatesMu.Unlock()
+			return "", err
+		}
+		if g.templates == nil {
+			g.templates = map[string]*template.Template{}
+		}
+		g.templates[text] = tmpl
+	}
+	g.templatesMu.Unlock()
+
+	var b strings.Builder
+	if err := tmpl.Execute(&b, nil); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+// digits generates n random digits
+func (g *Generator) digits(n int) string {
+	b := make([]byte, n)
+	for i := range b {
+		b[i] = byte('0' + g.IntRange(0, 10))
+	}
+	return string(b)
+}
+
+// letters generates n random lower case letters
+func (g *Generator) letters(n int) string {
+	b := make([]byte, n)
+	for i := range b {
+		b[i] = byte('a' + g.IntRange(0, 26))
+	}
+	return string(b)
+}
+
+// oneOf picks one of choices
+func (g *Generator) oneOf(choices ...string) string {
+	if len(choices) == 0 {
+		return ""
+	}
+	return choices[g.rand.Int()%len(choices)]
+}
@@ -0,0 +1,89 @@
+package lorem
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+type StructWithTemplates struct {
+	Order    string   `lorem:"template,ORD-{{year}}-{{digits 5}}"`
+	Email    string   `lorem:"template,{{word 3 6}}.{{word 3 6}}@example.com"`
+	Commas   string   `lorem:"template,{{firstname}}, {{lastname}}"`
+	Custom   string   `lorem:"template,{{sku 3}}/{{upper (letters 2)}}"`
+	Range    string   `lorem:"template,{{intRange 10 20}}"`
+	Time     string   `lorem:"template,{{(time).Format \"2006\"}}"`
+	Pointer  *string  `lorem:"template,{{oneof \"a\" \"b\"}}"`
+	Slice    []string `lorem:"[2,2]template,{{uuid}}"`
+	Currency Currency `lorem:"template,{{oneof \"USD\" \"EUR\"}}"`
+}
+
+func TestTemplates(t *testing.T) {
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	var ss StructWithTemplates
+	if err := Fill(&ss, WithNow(now)); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	if !regexp.MustCompile(`^ORD-2024-\d{5}$`).MatchString(ss.Order) {
+		t.Errorf("Order: expected ORD-2024-<5 digits>, got %s", ss.Order)
+	}
+	if !regexp.MustCompile(`^\w{3,6}\.\w{3,6}@example\.com$`).MatchString(ss.Email) {
+		t.Errorf("Email: expected <word>.<word>@example.com, got %s", ss.Email)
+	}
+	if strings.Count(ss.Commas, ", ") != 1 {
+		t.Errorf("Commas: expected last, first, got %s", ss.Commas)
+	}
+	if !regexp.MustCompile(`^SKU-777/[A-Z]{2}$`).MatchString(ss.Custom) {
+		t.Errorf("Custom: expected SKU-777/<2 letters>, got %s", ss.Custom)
+	}
+	if n, err := strconv.Atoi(ss.Range); err != nil || n < 10 || n >= 20 {
+		t.Errorf("Range: expected 10 <= n < 20, got %s", ss.Range)
+	}
+	if ss.Time != "2023" && ss.Time != "2024" {
+		t.Errorf("Time: expected 2023 or 2024, got %s", ss.Time)
+	}
+	if ss.Pointer == nil || (*ss.Pointer != "a" && *ss.Pointer != "b") {
+		t.Errorf("Pointer: expected a or b, got %v", ss.Pointer)
+	}
+	for _, s := range ss.Slice {
+		if len(s) != 36 {
+			t.Errorf("Slice: expected uuid, got %s", s)
+		}
+	}
+	if ss.Currency != USD && ss.Currency != EUR {
+		t.Errorf("Currency: expected USD or EUR, got %d", ss.Currency)
+	}
+}
+
+func TestTemplateKindNames(t *testing.T) {
+	// tenant-id isn't an identifier, so it's only called through kind
+	var ss struct {
+		Tag    string `lorem:"tenant-id,7"`
+		Tenant string `lorem:"template,{{kind \"tenant-id\" 7}}/{{sku 2}}"`
+	}
+	if err := Fill(&ss); err != nil {
+		t.Fatal(err.Error())
+	}
+	if ss.Tag != "tenant-7" || ss.Tenant != "tenant-7/SKU-77" {
+		t.Errorf("expected tenant-7 and tenant-7/SKU-77, got %q and %q", ss.Tag, ss.Tenant)
+	}
+}
+
+func TestTemplateErrors(t *testing.T) {
+	var parse struct {
+		Bad string `lorem:"template,{{nosuchfunc}}"`
+	}
+	if err := Fill(&parse); err == nil {
+		t.Error("Expected error, got nil")
+	}
+
+	var exec struct {
+		Bad string `lorem:"template,{{broken}}"`
+	}
+	if err := Fill(&exec); err == nil || !strings.Contains(err.Error(), "broken kind") {
+		t.Errorf("Expected broken kind error, got %v", err)
+	}
+}
@@ -0,0 +1,80 @@
+package lorem
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// TypeFunc generates a value of a registered type.
+// tag is the lorem tag of the field being filled.
+type TypeFunc func(g *Generator, tag string) (reflect.Value, error)
+
+var (
+	typeFuncsMu sync.RWMutex
+	typeFuncs   = map[reflect.Type]TypeFunc{}
+)
+
+// RegisterType teaches Fill to generate values of typ with fn, for types
+// that can't implement Decoder themselves (decimal.Decimal, sql.NullString
+// and so on). Registered types are checked before anything else, so they
+// also replace the built in handling of a type, such as time.Time.
+// Fields of type *typ are allocated and filled with fn too.
+// RegisterType panics if typ is nil, fn is nil or typ is registered twice.
+func RegisterType(typ reflect.Type, fn TypeFunc) {
+	if typ == nil {
+		panic("lorem: RegisterType typ is nil")
+	}
+	if fn == nil {
+		panic("lorem: RegisterType fn is nil for type " + typ.String())
+	}
+
+	typeFuncsMu.Lock()
+	defer typeFuncsMu.Unlock()
+	if _, dup := typeFuncs[typ]; dup {
+		panic("lorem: RegisterType called twice for type " + typ.String())
+	}
+	typeFuncs[typ] = fn
+}
+
+func registeredType(typ reflect.Type) (TypeFunc, bool) {
+	typeFuncsMu.RLock()
+	defer typeFuncsMu.RUnlock()
+	fn, ok := typeFuncs[typ]
+	return fn, ok
+}
+
+// fillRegistered fills field with the TypeFunc registered for its
+// type, or the type it points to. It returns false if there is none.
+func (g *Generator) fillRegistered(tag string, field reflect.Value) (bool, error) {
+	typ := field.Type()
+	fn, ok := registeredType(typ)
+	if !ok && typ.Kind() == reflect.Ptr {
+		if fn, ok = registeredType(typ.Elem()); ok {
+			if field.IsNil() {
+				field.Set(reflect.New(typ.Elem()))
+			}
+			field = field.Elem()
+			typ = typ.Elem()
+		}
+	}
+	if !ok {
+		return false, nil
+	}
+
+	v, err := fn(g, tag)
+	if err != nil {
+		return true, err
+	}
+	switch {
+	case !v.IsValid():
+		// leave it as it is
+	case v.Type().AssignableTo(typ):
+		field.Set(v)
+	case v.Type().ConvertibleTo(typ):
+		field.Set(v.Convert(typ))
+	default:
+		return true, fmt.Errorf("registered type %s generated %s", typ, v.Type())
+	}
+	return true, nil
+}
@@ -0,0 +1,91 @@
+package lorem
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// Money stands in for a third party type with unexported internals
+type Money struct {
+	cents    int64
+	currency string
+}
+
+type Celsius float64
+
+type Unfillable struct {
+	value string
+}
+
+func init() {
+	RegisterType(reflect.TypeOf(Money{}), func(g *Generator, tag string) (reflect.Value, error) {
+		currency := "USD"
+		if tag != "" {
+			currency = tag
+		}
+		return reflect.ValueOf(Money{cents: int64(IntRange(100, 10000)), currency: currency}), nil
+	})
+	RegisterType(reflect.TypeOf(Celsius(0)), func(g *Generator, tag string) (reflect.Value, error) {
+		return reflect.ValueOf(21.5), nil
+	})
+	RegisterType(reflect.TypeOf(Unfillable{}), func(g *Generator, tag string) (reflect.Value, error) {
+		return reflect.Value{}, errors.New("unfillable")
+	})
+}
+
+type StructWithRegisteredTypes struct {
+	Price        Money
+	PriceInEuros Money `lorem:"EUR"`
+	PricePointer *Money
+	Prices       []Money `lorem:"[3,3]CAD"`
+	Temperature  Celsius
+}
+
+func TestRegisterType(t *testing.T) {
+	var ss StructWithRegisteredTypes
+	if err := Fill(&ss); err != nil {
+		t.Error(err.Error())
+	}
+
+	if ss.Price.cents < 100 || ss.Price.currency != "USD" {
+		t.Errorf("Price: expected USD price of at least 100 cents, got %v", ss.Price)
+	}
+	if ss.PriceInEuros.currency != "EUR" {
+		t.Errorf("PriceInEuros: expected %s, got %s", "EUR", ss.PriceInEuros.currency)
+	}
+	if ss.PricePointer == nil || ss.PricePointer.cents < 100 {
+		t.Errorf("PricePointer: expected pointer to be filled, got %v", ss.PricePointer)
+	}
+	if len(ss.Prices) != 3 {
+		t.Errorf("Prices: expected %d entries, got %d", 3, len(ss.Prices))
+	}
+	for _, p := range ss.Prices {
+		if p.currency != "CAD" {
+			t.Errorf("Prices: expected %s, got %s", "CAD", p.currency)
+		}
+	}
+	if ss.Temperature != 21.5 {
+		t.Errorf("Temperature: expected %f, got %f", 21.5, ss.Temperature)
+	}
+}
+
+func TestRegisterTypeErrors(t *testing.T) {
+	var ss struct {
+		Unfillable Unfillable
+	}
+	err := Fill(&ss)
+	if err == nil || !strings.Contains(err.Error(), "unfillable") {
+		t.Errorf("Expected unfillable error, got %v", err)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected RegisterType to panic when called twice")
+		}
+	}()
+	RegisterType(reflect.TypeOf(Money{}), func(g *Generator, tag string) (reflect.Value, error) {
+		return reflect.Value{}, nil
+	})
+}
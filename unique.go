@@ -0,0 +1,95 @@
+package lorem
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// ErrNotUnique is returned when a field tagged unique can't be given a
+// value that the generator hasn't already used, such as `word,2,2,unique`
+// once most of the two letter words are taken
+var ErrNotUnique = errors.New("could not generate a unique value")
+
+// defaultUniqueTries is how many values are tried for a unique field
+const defaultUniqueTries = 100
+
+// WithUniqueTries sets how many values are tried for a field tagged
+// unique before giving up with ErrNotUnique. The default is 100.
+func WithUniqueTries(n int) Option {
+	return func(g *Generator) {
+		g.uniqueTries = n
+	}
+}
+
+// uniqueKey identifies a unique field, so values are unique per
+// field wherever its struct appears
+type uniqueKey struct {
+	typ   reflect.Type
+	field string
+}
+
+// parseUnique splits the unique option out of tag
+func parseUnique(tag string) (string, bool) {
+	args := strings.Split(tag, ",")
+	if args[0] == "" {
+		// literals can contain anything
+		return tag, false
+	}
+	unique := false
+	rest := args[:0:0]
+	for _, arg := range args {
+		if arg == "unique" {
+			unique = true
+			continue
+		}
+		rest = append(rest, arg)
+	}
+	return strings.Join(rest, ","), unique
+}
+
+// fillUnique calls fill until field of the struct typ has a value not
+// used before by this generator
+func (g *Generator) fillUnique(typ reflect.Type, i int, field reflect.Value, fill func() error) error {
+	if !field.CanSet() {
+		return fill()
+	}
+	key := uniqueKey{typ, typ.Field(i).Name}
+	for try := 0; try < g.uniqueTries; try++ {
+		if err := fill(); err != nil {
+			return err
+		}
+		if g.claimUnique(key, fmt.Sprint(reflect.Indirect(field))) {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w for %s after %d tries", ErrNotUnique, g.fieldName(typ.Field(i)), g.uniqueTries)
+}
+
+// claimUnique records value for key, returning false if it was already used
+func (g *Generator) claimUnique(key uniqueKey, value string) bool {
+	g.uniqueMu.Lock()
+	defer g.uniqueMu.Unlock()
+	if g.unique == nil {
+		g.unique = map[uniqueKey]map[string]bool{}
+	}
+	used, ok := g.unique[key]
+	if !ok {
+		used = map[string]bool{}
+		g.unique[key] = used
+	}
+	if used[value] {
+		return false
+	}
+	used[value] = true
+	return true
+}
+
+// ResetUnique forgets the values used for unique fields,
+// so they can be used again
+func (g *Generator) ResetUnique() {
+	g.uniqueMu.Lock()
+	defer g.uniqueMu.Unlock()
+	g.unique = nil
+}
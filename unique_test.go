@@ -0,0 +1,87 @@
+package lorem
+
+import (
+	"errors"
+	"testing"
+)
+
+type UniqueUser struct {
+	Email    string  `lorem:"email,unique"`
+	Username *string `lorem:"word,3,10,unique"`
+	Team     string  `lorem:"oneof,red|blue"`
+}
+
+func TestUnique(t *testing.T) {
+	var users struct {
+		Users []UniqueUser `lorem:"[2000,2001]"`
+	}
+	if err := Fill(&users); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	emails := map[string]bool{}
+	usernames := map[string]bool{}
+	for _, u := range users.Users {
+		if emails[u.Email] {
+			t.Errorf("Email: expected unique, got %s twice", u.Email)
+		}
+		emails[u.Email] = true
+		if usernames[*u.Username] {
+			t.Errorf("Username: expected unique, got %s twice", *u.Username)
+		}
+		usernames[*u.Username] = true
+	}
+}
+
+func TestUniqueAcrossFills(t *testing.T) {
+	g := NewGenerator()
+	seen := map[string]bool{}
+	for i := 0; i < 3; i++ {
+		var ss struct {
+			Letter string `lorem:"oneof,a|b|c,unique"`
+		}
+		if err := g.Fill(&ss); err != nil {
+			t.Fatal(err.Error())
+		}
+		if seen[ss.Letter] {
+			t.Errorf("Letter: expected unique, got %s twice", ss.Letter)
+		}
+		seen[ss.Letter] = true
+	}
+
+	var ss struct {
+		Letter string `lorem:"oneof,a|b|c,unique"`
+	}
+	if err := g.Fill(&ss); !errors.Is(err, ErrNotUnique) {
+		t.Errorf("Expected ErrNotUnique, got %v", err)
+	}
+
+	g.ResetUnique()
+	if err := g.Fill(&ss); err != nil {
+		t.Errorf("Expected no error after ResetUnique, got %v", err)
+	}
+}
+
+func TestUniqueExhausted(t *testing.T) {
+	var ss struct {
+		Words []struct {
+			Word string `lorem:"word,2,2,unique"`
+		} `lorem:"[1000,1001]"`
+	}
+	err := Fill(&ss, WithUniqueTries(10))
+	if !errors.Is(err, ErrNotUnique) {
+		t.Errorf("Expected ErrNotUnique, got %v", err)
+	}
+}
+
+func TestParseUnique(t *testing.T) {
+	if tag, unique := parseUnique("email,unique"); tag != "email" || !unique {
+		t.Errorf("Expected email and true, got %s and %t", tag, unique)
+	}
+	if tag, unique := parseUnique("word,2,4"); tag != "word,2,4" || unique {
+		t.Errorf("Expected word,2,4 and false, got %s and %t", tag, unique)
+	}
+	if tag, unique := parseUnique(",unique"); tag != ",unique" || unique {
+		t.Errorf("Expected literal ,unique and false, got %s and %t", tag, unique)
+	}
+}
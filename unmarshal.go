@@ -0,0 +1,63 @@
+package lorem
+
+import (
+	"encoding"
+	"encoding/json"
+	"reflect"
+)
+
+// textDecoder feeds the example to UnmarshalText
+type textDecoder struct {
+	encoding.TextUnmarshaler
+}
+
+func (d textDecoder) LoremDecode(tag, example string) error {
+	return d.UnmarshalText([]byte(example))
+}
+
+// jsonDecoder feeds the example to UnmarshalJSON. An example that is
+// already JSON (a number, true, false or an object written out in a
+// literal tag) is passed as is, anything else as a JSON string.
+type jsonDecoder struct {
+	json.Unmarshaler
+}
+
+func (d jsonDecoder) LoremDecode(tag, example string) error {
+	fragment := []byte(example)
+	if !json.Valid(fragment) {
+		fragment, _ = json.Marshal(example)
+	}
+	return d.UnmarshalJSON(fragment)
+}
+
+// unmarshalerFrom returns a Decoder for fields implementing
+// encoding.TextUnmarshaler or json.Unmarshaler, preferring text.
+// time.Time is left to fillRec, which knows how to make them.
+func unmarshalerFrom(field reflect.Value) Decoder {
+	if !field.CanAddr() || field.Type() == timeType || field.Type() == reflect.PtrTo(timeType) {
+		return nil
+	}
+	if field.Kind() == reflect.Ptr {
+		if field.IsNil() {
+			if !field.Type().Implements(textUnmarshalerType) && !field.Type().Implements(jsonUnmarshalerType) {
+				return nil
+			}
+			field.Set(reflect.New(field.Type().Elem()))
+		}
+	} else {
+		field = field.Addr()
+	}
+
+	switch u := field.Interface().(type) {
+	case encoding.TextUnmarshaler:
+		return textDecoder{u}
+	case json.Unmarshaler:
+		return jsonDecoder{u}
+	}
+	return nil
+}
+
+var (
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+	jsonUnmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+)
@@ -0,0 +1,121 @@
+package lorem
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"strings"
+	"testing"
+	"time"
+)
+
+// Currency only accepts known codes
+type Currency int
+
+const (
+	USD Currency = iota + 1
+	EUR
+	CAD
+)
+
+func (c *Currency) UnmarshalText(text []byte) error {
+	switch string(text) {
+	case "USD":
+		*c = USD
+	case "EUR":
+		*c = EUR
+	case "CAD":
+		*c = CAD
+	default:
+		return fmt.Errorf("unknown currency %s", text)
+	}
+	return nil
+}
+
+// Labels only unmarshals from JSON
+type Labels struct {
+	values []string
+}
+
+func (l *Labels) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		l.values = strings.Fields(s)
+		return nil
+	}
+	return json.Unmarshal(data, &l.values)
+}
+
+type StructWithUnmarshalers struct {
+	Currency        Currency   `lorem:"oneof,USD|EUR"`
+	CurrencyPointer *Currency  `lorem:",CAD"`
+	Currencies      []Currency `lorem:"[3,3]oneof,CAD"`
+	Untagged        Currency
+	Labels          Labels  `lorem:"sentence,3,3"`
+	LabelsLiteral   *Labels `lorem:",[\"a\"]"`
+	IP              net.IP  `lorem:",10.0.0.1"`
+	Time            time.Time
+	TimeTagged      time.Time `lorem:"time"`
+}
+
+func TestUnmarshalers(t *testing.T) {
+	var ss StructWithUnmarshalers
+	if err := Fill(&ss); err != nil {
+		t.Error(err.Error())
+	}
+
+	if ss.Currency != USD && ss.Currency != EUR {
+		t.Errorf("Currency: expected USD or EUR, got %d", ss.Currency)
+	}
+	if ss.CurrencyPointer == nil || *ss.CurrencyPointer != CAD {
+		t.Errorf("CurrencyPointer: expected CAD, got %v", ss.CurrencyPointer)
+	}
+	if len(ss.Currencies) != 3 {
+		t.Errorf("Currencies: expected %d entries, got %d", 3, len(ss.Currencies))
+	}
+	for _, c := range ss.Currencies {
+		if c != CAD {
+			t.Errorf("Currencies: expected CAD, got %d", c)
+		}
+	}
+	if len(ss.Labels.values) != 3 {
+		t.Errorf("Labels: expected 3 words, got %v", ss.Labels.values)
+	}
+	if ss.LabelsLiteral == nil || len(ss.LabelsLiteral.values) != 1 || ss.LabelsLiteral.values[0] != "a" {
+		t.Errorf("LabelsLiteral: expected [a], got %v", ss.LabelsLiteral)
+	}
+	if ss.IP.String() != "10.0.0.1" {
+		t.Errorf("IP: expected %s, got %s", "10.0.0.1", ss.IP)
+	}
+	if !ss.Time.IsZero() {
+		t.Errorf("Time: expected untagged time to be left zero, got %s", ss.Time)
+	}
+	if ss.TimeTagged.IsZero() {
+		t.Error("TimeTagged: expected time to be set")
+	}
+}
+
+func TestUnmarshalerErrors(t *testing.T) {
+	var ss struct {
+		Currency Currency `lorem:",GBP"`
+	}
+	if err := Fill(&ss); err == nil || !strings.Contains(err.Error(), "unknown currency") {
+		t.Errorf("Expected unknown currency error, got %v", err)
+	}
+}
+
+func TestOneOfKind(t *testing.T) {
+	seen := map[string]bool{}
+	for i := 0; i < 100; i++ {
+		var ss struct {
+			Choice string `lorem:"oneof,a|b,c|d"`
+		}
+		if err := Fill(&ss); err != nil {
+			t.Error(err.Error())
+		}
+		seen[ss.Choice] = true
+	}
+	if len(seen) != 3 || !seen["a"] || !seen["b,c"] || !seen["d"] {
+		t.Errorf("Choice: expected a, b,c and d, got %v", seen)
+	}
+}
@@ -0,0 +1,311 @@
+package lorem
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// validTries is how many values of a format like email are tried to
+// get one of a length the validate rules allow
+const validTries = 100
+
+// WithValidate makes fields with a validate tag (as used by
+// github.com/go-playground/validator) get values that pass it.
+// A lorem tag (or spec) on the same field wins over the validate tag.
+// Supported rules are required, omitempty, len, eq, min, max, gt, gte,
+// lt, lte, oneof, email, url, uri, http_url, uuid, uuid4, ip, ipv4, ipv6,
+// hostname, fqdn, alpha, alphanum, numeric, lowercase, uppercase and dive;
+// anything else is ignored.
+func WithValidate() Option {
+	return func(g *Generator) {
+		g.validate = true
+	}
+}
+
+// validateRules are the parsed rules of a validate tag, with
+// the rules after dive applying to each entry of a slice or map
+type validateRules struct {
+	rules map[string]string
+	dive  *validateRules
+}
+
+// validateRules returns the field's parsed validate tag, or nil if
+// there is none or the generator doesn't use them
+func (g *Generator) validateRules(sf reflect.StructField) *validateRules {
+	if !g.validate {
+		return nil
+	}
+	tag := sf.Tag.Get("validate")
+	if tag == "" || tag == "-" {
+		return nil
+	}
+	return parseValidate(tag)
+}
+
+func parseValidate(tag string) *validateRules {
+	r := &validateRules{rules: map[string]string{}}
+	parts := strings.Split(tag, ",")
+	inKeys := false
+	for i, part := range parts {
+		switch {
+		case part == "dive":
+			r.dive = parseValidate(strings.Join(parts[i+1:], ","))
+			return r
+		case part == "keys":
+			// map keys are filled as if untagged
+			inKeys = true
+			continue
+		case part == "endkeys":
+			inKeys = false
+			continue
+		case inKeys:
+			continue
+		}
+		// only the first of a|b is used
+		name, param, _ := strings.Cut(strings.Split(part, "|")[0], "=")
+		r.rules[name] = param
+	}
+	return r
+}
+
+// oneOfRegex matches the choices of oneof as validator splits them,
+// with choices containing spaces in single quotes
+var oneOfRegex = regexp.MustCompile(`'[^']*'|\S+`)
+
+// splitOneOf returns the choices of a oneof rule, unquoted
+func splitOneOf(param string) []string {
+	choices := oneOfRegex.FindAllString(param, -1)
+	for i, choice := range choices {
+		choices[i] = strings.ReplaceAll(choice, "'", "")
+	}
+	return choices
+}
+
+func (r *validateRules) has(names ...string) bool {
+	for _, name := range names {
+		if _, ok := r.rules[name]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *validateRules) number(name string) (float64, bool) {
+	param, ok := r.rules[name]
+	if !ok {
+		return 0, false
+	}
+	f, err := strconv.ParseFloat(param, 64)
+	return f, err == nil
+}
+
+// bounds returns the inclusive range allowed by the size rules,
+// using defLo and defHi for the ends that aren't given. whole is
+// true for lengths and integers, where gt 5 means at least 6.
+func (r *validateRules) bounds(defLo, defHi float64, whole bool) (float64, float64) {
+	if v, ok := r.number("len"); ok {
+		return v, v
+	}
+	if v, ok := r.number("eq"); ok {
+		return v, v
+	}
+
+	step := func(v, dir float64) float64 {
+		if whole {
+			return v + dir
+		}
+		return math.Nextafter(v, v+dir)
+	}
+	lo, hi := math.NaN(), math.NaN()
+	for _, name := range []string{"min", "gte"} {
+		if v, ok := r.number(name); ok {
+			lo = v
+		}
+	}
+	if v, ok := r.number("gt"); ok {
+		lo = step(v, 1)
+	}
+	for _, name := range []string{"max", "lte"} {
+		if v, ok := r.number(name); ok {
+			hi = v
+		}
+	}
+	if v, ok := r.number("lt"); ok {
+		hi = step(v, -1)
+	}
+
+	switch {
+	case math.IsNaN(lo) && math.IsNaN(hi):
+		return defLo, defHi
+	case math.IsNaN(lo):
+		lo = math.Min(defLo, hi-(defHi-defLo))
+		if defLo <= hi {
+			lo = defLo
+		}
+	case math.IsNaN(hi):
+		hi = math.Max(defHi, lo+(defHi-defLo))
+		if lo <= defHi {
+			hi = defHi
+		}
+	}
+	return lo, hi
+}
+
+// intBetween returns a random integer in [lo, hi]
+func (g *Generator) intBetween(lo, hi float64) float64 {
+	lo, hi = math.Ceil(lo), math.Floor(hi)
+	if hi <= lo {
+		return lo
+	}
+	return lo + math.Floor(g.rand.Float64()*(hi-lo+1))
+}
+
+// fillValidated fills field with a value that passes the rules
+func (g *Generator) fillValidated(path, alias string, r *validateRules, field reflect.Value) error {
+	if !field.CanSet() {
+		return nil
+	}
+	typ := field.Type()
+
+	if oneof, ok := r.rules["oneof"]; ok && field.Kind() != reflect.Ptr {
+		choices := splitOneOf(oneof)
+		if len(choices) > 0 {
+			return setValue(field, g.oneOf(choices...))
+		}
+	}
+
+	switch field.Kind() {
+	case reflect.Ptr:
+		if field.IsNil() {
+			field.Set(reflect.New(typ.Elem()))
+		}
+		return g.fillValidated(path, alias, r, field.Elem())
+	case reflect.String:
+		str, err := g.validString(r)
+		if err != nil {
+			return err
+		}
+		field.SetString(str)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		lo, hi := r.bounds(0, 1000, true)
+		bits := float64(typ.Bits())
+		lo = math.Max(lo, -math.Pow(2, bits-1))
+		hi = math.Min(hi, math.Pow(2, bits-1)-1)
+		field.SetInt(int64(g.intBetween(lo, hi)))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		lo, hi := r.bounds(0, 1000, true)
+		lo = math.Max(lo, 0)
+		hi = math.Min(hi, math.Pow(2, float64(typ.Bits()))-1)
+		field.SetUint(uint64(g.intBetween(lo, hi)))
+	case reflect.Float32, reflect.Float64:
+		lo, hi := r.bounds(0, 1000, false)
+		field.SetFloat(lo + g.rand.Float64()*(hi-lo))
+	case reflect.Slice:
+		lo, hi := r.bounds(1, 10, true)
+		size := int(g.intBetween(math.Max(lo, 0), hi))
+		sl := reflect.MakeSlice(typ, size, size)
+		for i := 0; i < size; i++ {
+			if err := g.fillEntry(path, alias, r.dive, sl.Index(i)); err != nil {
+				return err
+			}
+		}
+		field.Set(sl)
+	case reflect.Map:
+		lo, hi := r.bounds(1, 10, true)
+		size := int(g.intBetween(math.Max(lo, 0), hi))
+		m := reflect.MakeMapWithSize(typ, size)
+		for tries := 0; m.Len() < size && tries < size*10; tries++ {
+			key := reflect.New(typ.Key()).Elem()
+			if err := g.fillRec(path, alias, "", key); err != nil {
+				return err
+			}
+			value := reflect.New(typ.Elem()).Elem()
+			if err := g.fillEntry(path, alias, r.dive, value); err != nil {
+				return err
+			}
+			m.SetMapIndex(key, value)
+		}
+		field.Set(m)
+	case reflect.Bool:
+		if b, err := strconv.ParseBool(r.rules["eq"]); err == nil {
+			field.SetBool(b)
+			return nil
+		}
+		return g.fillRec(path, alias, "", field)
+	default:
+		return g.fillRec(path, alias, "", field)
+	}
+	return nil
+}
+
+// fillEntry fills a slice or map entry using the rules after dive
+func (g *Generator) fillEntry(path, alias string, dive *validateRules, entry reflect.Value) error {
+	if dive == nil {
+		return g.fillRec(path, alias, "", entry)
+	}
+	return g.fillValidated(path, alias, dive, entry)
+}
+
+// validString generates a string that passes the rules
+func (g *Generator) validString(r *validateRules) (string, error) {
+	if eq, ok := r.rules["eq"]; ok {
+		return eq, nil
+	}
+
+	var format func() string
+	switch {
+	case r.has("email"):
+		format = g.Email
+	case r.has("url", "uri", "http_url"):
+		format = g.URL
+	case r.has("uuid", "uuid4"):
+		format = g.UUID
+	case r.has("ipv6"):
+		format = g.IPv6
+	case r.has("ip", "ipv4"):
+		format = g.IPv4
+	case r.has("hostname", "hostname_rfc1123", "fqdn"):
+		format = g.Host
+	}
+	if format != nil {
+		lo, hi := r.bounds(0, math.MaxInt32, true)
+		for try := 0; try < validTries; try++ {
+			if str := format(); float64(len(str)) >= lo && float64(len(str)) <= hi {
+				return str, nil
+			}
+		}
+		return "", fmt.Errorf("could not generate a value between %v and %v long for validate rules %v", lo, hi, r.rules)
+	}
+
+	lo, hi := r.bounds(1, 20, true)
+	n := int(g.intBetween(math.Max(lo, 0), hi))
+	var str string
+	switch {
+	case r.has("numeric", "number"):
+		str = g.digits(n)
+	case r.has("alpha"):
+		str = g.letters(n)
+	case r.has("alphanum"):
+		b := []byte(g.letters(n))
+		for i := range b {
+			if g.rand.Int()%3 == 0 {
+				b[i] = byte('0' + g.IntRange(0, 10))
+			}
+		}
+		str = string(b)
+	default:
+		str = g.Text(n)
+	}
+
+	switch {
+	case r.has("uppercase"):
+		str = strings.ToUpper(str)
+	case r.has("lowercase"):
+		str = strings.ToLower(str)
+	}
+	return str, nil
+}
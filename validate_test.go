@@ -0,0 +1,147 @@
+package lorem
+
+import (
+	"net"
+	"net/mail"
+	"strings"
+	"testing"
+	"unicode"
+)
+
+type ValidateStruct struct {
+	Username string         `validate:"required,alphanum,min=3,max=12"`
+	Email    string         `validate:"required,email"`
+	IP       string         `validate:"ip"`
+	Role     string         `validate:"oneof=admin user guest"`
+	Age      int            `validate:"gte=18,lte=99"`
+	Level    uint8          `validate:"gt=200"`
+	Score    float64        `validate:"min=0.5,max=1"`
+	Code     string         `validate:"len=6,numeric"`
+	Shout    string         `validate:"uppercase,max=8"`
+	Bio      string         `validate:"min=30,max=40"`
+	Tags     []string       `validate:"min=2,max=4,dive,oneof=red green"`
+	Counts   map[string]int `validate:"len=3,dive,keys,alpha,endkeys,max=5"`
+	Nick     *string        `validate:"omitempty,lowercase,len=4"`
+	Active   bool           `validate:"eq=true"`
+	Override string         `lorem:",literal" validate:"email"`
+	Plain    string
+	Extra    map[string]string `validate:"max=0"`
+}
+
+func TestWithValidate(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		var vs ValidateStruct
+		if err := Fill(&vs, WithValidate()); err != nil {
+			t.Fatal(err.Error())
+		}
+
+		if len(vs.Username) < 3 || len(vs.Username) > 12 || strings.IndexFunc(vs.Username, func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+		}) >= 0 {
+			t.Errorf("Username: expected 3 to 12 letters and digits, got %q", vs.Username)
+		}
+		if _, err := mail.ParseAddress(vs.Email); err != nil {
+			t.Errorf("Email: expected an email address, got %q", vs.Email)
+		}
+		if net.ParseIP(vs.IP) == nil {
+			t.Errorf("IP: expected an IP address, got %q", vs.IP)
+		}
+		if vs.Role != "admin" && vs.Role != "user" && vs.Role != "guest" {
+			t.Errorf("Role: expected one of admin, user and guest, got %q", vs.Role)
+		}
+		if vs.Age < 18 || vs.Age > 99 {
+			t.Errorf("Age: expected between 18 and 99, got %d", vs.Age)
+		}
+		if vs.Level <= 200 {
+			t.Errorf("Level: expected more than 200, got %d", vs.Level)
+		}
+		if vs.Score < 0.5 || vs.Score > 1 {
+			t.Errorf("Score: expected between 0.5 and 1, got %v", vs.Score)
+		}
+		if len(vs.Code) != 6 || strings.Trim(vs.Code, "0123456789") != "" {
+			t.Errorf("Code: expected 6 digits, got %q", vs.Code)
+		}
+		if len(vs.Shout) > 8 || vs.Shout != strings.ToUpper(vs.Shout) {
+			t.Errorf("Shout: expected at most 8 upper case characters, got %q", vs.Shout)
+		}
+		if len(vs.Bio) < 30 || len(vs.Bio) > 40 || strings.TrimSpace(vs.Bio) != vs.Bio {
+			t.Errorf("Bio: expected 30 to 40 characters of text, got %q", vs.Bio)
+		}
+		if len(vs.Tags) < 2 || len(vs.Tags) > 4 {
+			t.Errorf("Tags: expected 2 to 4 tags, got %v", vs.Tags)
+		}
+		for _, tag := range vs.Tags {
+			if tag != "red" && tag != "green" {
+				t.Errorf("Tags: expected red or green, got %q", tag)
+			}
+		}
+		if len(vs.Counts) != 3 {
+			t.Errorf("Counts: expected 3 entries, got %v", vs.Counts)
+		}
+		for _, count := range vs.Counts {
+			if count < 0 || count > 5 {
+				t.Errorf("Counts: expected values up to 5, got %d", count)
+			}
+		}
+		if vs.Nick == nil || len(*vs.Nick) != 4 || *vs.Nick != strings.ToLower(*vs.Nick) {
+			t.Errorf("Nick: expected 4 lower case characters, got %v", vs.Nick)
+		}
+		if !vs.Active {
+			t.Error("Active: expected true")
+		}
+		if vs.Override != "literal" {
+			t.Errorf("Override: expected the lorem tag to win, got %q", vs.Override)
+		}
+		if vs.Plain == "" {
+			t.Error("Plain: expected untagged field to be filled")
+		}
+		if len(vs.Extra) != 0 {
+			t.Errorf("Extra: expected no entries, got %v", vs.Extra)
+		}
+	}
+}
+
+func TestWithoutValidate(t *testing.T) {
+	var vs ValidateStruct
+	if err := Fill(&vs); err != nil {
+		t.Fatal(err.Error())
+	}
+	if vs.Role == "admin" || vs.Role == "user" || vs.Role == "guest" {
+		t.Errorf("Role: expected validate tags to be ignored by default, got %q", vs.Role)
+	}
+}
+
+func TestWithValidateImpossible(t *testing.T) {
+	type Short struct {
+		Email string `validate:"email,max=3"`
+	}
+	var s Short
+	if err := Fill(&s, WithValidate()); err == nil {
+		t.Errorf("expected an error for an email of at most 3 characters, got %q", s.Email)
+	}
+}
+
+func TestWithValidateQuotedOneOf(t *testing.T) {
+	type Listing struct {
+		Status string `validate:"oneof='in stock' 'sold out' gone"`
+	}
+	for i := 0; i < 20; i++ {
+		var l Listing
+		if err := Fill(&l, WithValidate()); err != nil {
+			t.Fatal(err.Error())
+		}
+		if l.Status != "in stock" && l.Status != "sold out" && l.Status != "gone" {
+			t.Errorf("Status: expected a quoted choice to be kept whole, got %q", l.Status)
+		}
+	}
+}
+
+func TestWithValidateUniqueTries(t *testing.T) {
+	type Contact struct {
+		Email string `validate:"email,max=40"`
+	}
+	var c Contact
+	if err := Fill(&c, WithValidate(), WithUniqueTries(0)); err != nil || c.Email == "" {
+		t.Errorf("expected formats not to depend on WithUniqueTries, got %q and %v", c.Email, err)
+	}
+}